@@ -1,8 +1,24 @@
 package spotifygo
 
+import (
+	"net/http"
+	"time"
+)
+
 // APIResponse represents a response from the Spotify API,
 // where JSONBody is the returned JSON.
+// Header contains the response headers (nil if no response was received);
+// Method and URL are the HTTP method and the final URL of the request;
+// Elapsed is the time the request took, including retries;
+// Attempts is the number of HTTP requests made (more than 1 if the request was retried);
+// FromCache is true if the response was served from a response cache.
 type APIResponse struct {
 	StatusCode int
 	JSONBody   string
+	Header     http.Header
+	Method     string
+	URL        string
+	Elapsed    time.Duration
+	Attempts   int
+	FromCache  bool
 }
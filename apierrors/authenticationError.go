@@ -8,10 +8,15 @@ import (
 
 // AuthenticationError represents an authentication error object
 // as per the documentation.
+// Response is the APIResponse the error was created from, which carries
+// the response headers and the request metadata.
+// Use errors.As to get an AuthenticationError from an error,
+// and errors.Is to compare it with sentinel errors, such as ErrUnauthorized.
 type AuthenticationError struct {
 	StatusCode       int
-	ErrorHighLevel   string `json:"error"`
-	ErrorDescription string `json:"error_description"`
+	ErrorHighLevel   string                `json:"error"`
+	ErrorDescription string                `json:"error_description"`
+	Response         spotifygo.APIResponse `json:"-"`
 }
 
 // NewAuthenticationError creates a new AuthenticationError
@@ -21,6 +26,7 @@ type AuthenticationError struct {
 func NewAuthenticationError(response spotifygo.APIResponse) TypedError {
 	var authError AuthenticationError
 	authError.StatusCode = response.StatusCode
+	authError.Response = response
 
 	if err := json.Unmarshal([]byte(response.JSONBody), &authError); err != nil {
 		return &BasicError{err}
@@ -37,3 +43,15 @@ func (authError *AuthenticationError) Error() string {
 func (authError *AuthenticationError) GetType() ErrorType {
 	return AuthenticationErrorType
 }
+
+// Is reports whether authError matches a sentinel error, such as ErrUnauthorized,
+// so that AuthenticationError can be checked with errors.Is.
+func (authError *AuthenticationError) Is(target error) bool {
+	if target == ErrUnauthorized &&
+		(authError.ErrorHighLevel == "invalid_client" || authError.ErrorHighLevel == "invalid_grant") {
+		return true
+	}
+
+	sentinel := statusCodeSentinel(authError.StatusCode)
+	return sentinel != nil && sentinel == target
+}
@@ -25,3 +25,9 @@ func NewBasicErrorFromError(err error) TypedError {
 func (err *BasicError) GetType() ErrorType {
 	return BasicErrorType
 }
+
+// Unwrap returns the wrapped error, so that errors.Is and errors.As
+// can inspect it.
+func (err *BasicError) Unwrap() error {
+	return err.error
+}
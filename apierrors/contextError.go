@@ -0,0 +1,30 @@
+package apierrors
+
+// ContextError represents an error caused by the cancellation or the deadline
+// expiration of the context.Context that was passed to a request.
+// Err is the error returned by the context's Err method, such as
+// context.Canceled or context.DeadlineExceeded.
+type ContextError struct {
+	Err error
+}
+
+// NewContextError creates a new ContextError from the error returned by ctx.Err().
+// Notice that this function returns TypedError and not ContextError.
+func NewContextError(err error) TypedError {
+	return &ContextError{err}
+}
+
+func (contextError *ContextError) Error() string {
+	return contextError.Err.Error()
+}
+
+// GetType returns the type of ContextError, so ContextError implements TypedError.
+func (contextError *ContextError) GetType() ErrorType {
+	return ContextErrorType
+}
+
+// Unwrap returns Err, so that errors.Is(err, context.Canceled) and
+// errors.Is(err, context.DeadlineExceeded) work.
+func (contextError *ContextError) Unwrap() error {
+	return contextError.Err
+}
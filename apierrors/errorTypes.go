@@ -10,6 +10,12 @@ const (
 	AuthenticationErrorType
 	// RestAPIErrorType is the type for RestAPIError.
 	RestAPIErrorType
+	// ContextErrorType is the type for ContextError.
+	ContextErrorType
+	// RateLimitErrorType is the type for RateLimitError.
+	RateLimitErrorType
+	// PlayerErrorType is the type for PlayerError.
+	PlayerErrorType
 )
 
 func (errorType ErrorType) String() (string, TypedError) {
@@ -17,6 +23,9 @@ func (errorType ErrorType) String() (string, TypedError) {
 		BasicErrorType:          "BasicError",
 		AuthenticationErrorType: "AuthenticationError",
 		RestAPIErrorType:        "RestAPIError",
+		ContextErrorType:        "ContextError",
+		RateLimitErrorType:      "RateLimitError",
+		PlayerErrorType:         "PlayerError",
 	}[errorType]
 	if !ok {
 		return "", NewBasicErrorFromString("Unknown error type")
@@ -0,0 +1,115 @@
+package apierrors
+
+// PlayerErrorReason represents the reason of a PlayerError.
+type PlayerErrorReason int
+
+const (
+	// UnknownReason is the 'UNKNOWN' reason, also used for unrecognized reasons.
+	UnknownReason PlayerErrorReason = iota
+	// NoPrevTrack is the 'NO_PREV_TRACK' reason.
+	NoPrevTrack
+	// NoNextTrack is the 'NO_NEXT_TRACK' reason.
+	NoNextTrack
+	// NoSpecificTrack is the 'NO_SPECIFIC_TRACK' reason.
+	NoSpecificTrack
+	// AlreadyPaused is the 'ALREADY_PAUSED' reason.
+	AlreadyPaused
+	// NotPaused is the 'NOT_PAUSED' reason.
+	NotPaused
+	// NotPlayingLocally is the 'NOT_PLAYING_LOCALLY' reason.
+	NotPlayingLocally
+	// NotPlayingTrack is the 'NOT_PLAYING_TRACK' reason.
+	NotPlayingTrack
+	// NotPlayingContext is the 'NOT_PLAYING_CONTEXT' reason.
+	NotPlayingContext
+	// EndlessContext is the 'ENDLESS_CONTEXT' reason.
+	EndlessContext
+	// ContextDisallow is the 'CONTEXT_DISALLOW' reason.
+	ContextDisallow
+	// AlreadyPlaying is the 'ALREADY_PLAYING' reason.
+	AlreadyPlaying
+	// RateLimited is the 'RATE_LIMITED' reason.
+	RateLimited
+	// RemoteControlDisallow is the 'REMOTE_CONTROL_DISALLOW' reason.
+	RemoteControlDisallow
+	// DeviceNotControllable is the 'DEVICE_NOT_CONTROLLABLE' reason.
+	DeviceNotControllable
+	// VolumeControlDisallow is the 'VOLUME_CONTROL_DISALLOW' reason.
+	VolumeControlDisallow
+	// NoActiveDevice is the 'NO_ACTIVE_DEVICE' reason.
+	NoActiveDevice
+	// PremiumRequired is the 'PREMIUM_REQUIRED' reason.
+	PremiumRequired
+)
+
+var playerErrorReasonStrings = map[PlayerErrorReason]string{
+	UnknownReason:         "UNKNOWN",
+	NoPrevTrack:           "NO_PREV_TRACK",
+	NoNextTrack:           "NO_NEXT_TRACK",
+	NoSpecificTrack:       "NO_SPECIFIC_TRACK",
+	AlreadyPaused:         "ALREADY_PAUSED",
+	NotPaused:             "NOT_PAUSED",
+	NotPlayingLocally:     "NOT_PLAYING_LOCALLY",
+	NotPlayingTrack:       "NOT_PLAYING_TRACK",
+	NotPlayingContext:     "NOT_PLAYING_CONTEXT",
+	EndlessContext:        "ENDLESS_CONTEXT",
+	ContextDisallow:       "CONTEXT_DISALLOW",
+	AlreadyPlaying:        "ALREADY_PLAYING",
+	RateLimited:           "RATE_LIMITED",
+	RemoteControlDisallow: "REMOTE_CONTROL_DISALLOW",
+	DeviceNotControllable: "DEVICE_NOT_CONTROLLABLE",
+	VolumeControlDisallow: "VOLUME_CONTROL_DISALLOW",
+	NoActiveDevice:        "NO_ACTIVE_DEVICE",
+	PremiumRequired:       "PREMIUM_REQUIRED",
+}
+
+func (reason PlayerErrorReason) String() (string, TypedError) {
+	reasonString, ok := playerErrorReasonStrings[reason]
+	if !ok {
+		return "", NewBasicErrorFromString("Unknown player error reason")
+	}
+
+	return reasonString, nil
+}
+
+// parsePlayerErrorReason returns the PlayerErrorReason of a reason string,
+// or UnknownReason if it is not recognized.
+func parsePlayerErrorReason(reasonString string) PlayerErrorReason {
+	for reason, knownReasonString := range playerErrorReasonStrings {
+		if knownReasonString == reasonString {
+			return reason
+		}
+	}
+
+	return UnknownReason
+}
+
+// PlayerError represents a player error object as per the documentation,
+// which is a regular error object with an additional reason.
+// RestAPIError is the underlying error object, which errors.As also finds.
+type PlayerError struct {
+	Reason       PlayerErrorReason
+	RestAPIError RestAPIError
+}
+
+func (playerError *PlayerError) Error() string {
+	return playerError.RestAPIError.Message
+}
+
+// Unwrap returns the underlying RestAPIError, so that errors.As and errors.Is work.
+func (playerError *PlayerError) Unwrap() error {
+	return &playerError.RestAPIError
+}
+
+// Is reports whether playerError matches a sentinel error, so that
+// a PREMIUM_REQUIRED PlayerError matches ErrPremiumRequired
+// and a RATE_LIMITED one matches ErrRateLimited.
+func (playerError *PlayerError) Is(target error) bool {
+	return (target == ErrPremiumRequired && playerError.Reason == PremiumRequired) ||
+		(target == ErrRateLimited && playerError.Reason == RateLimited)
+}
+
+// GetType returns the type of PlayerError, so PlayerError implements TypedError.
+func (playerError *PlayerError) GetType() ErrorType {
+	return PlayerErrorType
+}
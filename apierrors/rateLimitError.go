@@ -0,0 +1,63 @@
+package apierrors
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/taiypeo/spotifygo"
+)
+
+// RateLimitError represents a 429 Too Many Requests error of the REST API.
+// RetryAfter is the time to wait before retrying, parsed from the Retry-After
+// header (0 if the header is missing);
+// Endpoint is the template of the rate limited endpoint, such as "albums/{id}";
+// RestAPIError is the underlying error object, which errors.As also finds.
+type RateLimitError struct {
+	RetryAfter   time.Duration
+	Endpoint     string
+	RestAPIError RestAPIError
+}
+
+// NewRateLimitError creates a new RateLimitError from the given APIResponse,
+// Retry-After duration and endpoint template.
+// Notice that this function returns TypedError and not RateLimitError.
+func NewRateLimitError(
+	response spotifygo.APIResponse,
+	retryAfter time.Duration,
+	endpoint string,
+) TypedError {
+	rateLimitError := &RateLimitError{RetryAfter: retryAfter, Endpoint: endpoint}
+
+	// The body of a 429 response is not always an error object.
+	restAPIError, _, err := parseRestAPIError(response)
+	if err != nil {
+		restAPIError = RestAPIError{Response: response}
+	}
+	rateLimitError.RestAPIError = restAPIError
+	rateLimitError.RestAPIError.StatusCode = response.StatusCode
+	if rateLimitError.RestAPIError.Message == "" {
+		rateLimitError.RestAPIError.Message = "API rate limit exceeded"
+	}
+
+	return rateLimitError
+}
+
+func (rateLimitError *RateLimitError) Error() string {
+	return fmt.Sprintf(
+		"%s (%s, retry after %v)",
+		rateLimitError.RestAPIError.Message,
+		rateLimitError.Endpoint,
+		rateLimitError.RetryAfter,
+	)
+}
+
+// Unwrap returns the underlying RestAPIError, so that errors.As and errors.Is
+// (with ErrRateLimited) work.
+func (rateLimitError *RateLimitError) Unwrap() error {
+	return &rateLimitError.RestAPIError
+}
+
+// GetType returns the type of RateLimitError, so RateLimitError implements TypedError.
+func (rateLimitError *RateLimitError) GetType() ErrorType {
+	return RateLimitErrorType
+}
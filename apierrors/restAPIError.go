@@ -2,31 +2,58 @@ package apierrors
 
 import (
 	"encoding/json"
+	"net/http"
+	"strings"
 
 	"github.com/taiypeo/spotifygo"
 )
 
 // RestAPIError represents a REST API error object
 // as per the documentation (regular error object in the docs).
+// Response is the APIResponse the error was created from, which carries
+// the response headers and the request metadata.
+// Use errors.As to get a RestAPIError from an error,
+// and errors.Is to compare it with sentinel errors, such as ErrNotFound.
 type RestAPIError struct {
-	StatusCode int    `json:"status"`
-	Message    string `json:"message"`
+	StatusCode int                   `json:"status"`
+	Message    string                `json:"message"`
+	Response   spotifygo.APIResponse `json:"-"`
+}
+
+// parseRestAPIError parses the error object of the given APIResponse
+// and returns it with its reason, which only player error objects have.
+func parseRestAPIError(response spotifygo.APIResponse) (RestAPIError, string, error) {
+	var errorObject struct {
+		Error struct {
+			RestAPIError
+			Reason string `json:"reason"`
+		} `json:"error"`
+	}
+
+	if err := json.Unmarshal([]byte(response.JSONBody), &errorObject); err != nil {
+		return RestAPIError{}, "", err
+	}
+
+	errorObject.Error.Response = response
+	return errorObject.Error.RestAPIError, errorObject.Error.Reason, nil
 }
 
 // NewRestAPIError creates a new RestAPIError
 // from the given APIResponse.
+// If the error object has a reason (player endpoints), a PlayerError is returned instead.
 // If json.Unmarshal failed, will return a BasicError, so
 // check for the type of the returned value using GetType.
 func NewRestAPIError(response spotifygo.APIResponse) TypedError {
-	var restAPIError struct {
-		Error RestAPIError `json:"error"`
+	restAPIError, reason, err := parseRestAPIError(response)
+	if err != nil {
+		return &BasicError{err}
 	}
 
-	if err := json.Unmarshal([]byte(response.JSONBody), &restAPIError); err != nil {
-		return &BasicError{err}
+	if reason != "" {
+		return &PlayerError{Reason: parsePlayerErrorReason(reason), RestAPIError: restAPIError}
 	}
 
-	return &(restAPIError.Error)
+	return &restAPIError
 }
 
 func (restAPIError *RestAPIError) Error() string {
@@ -37,3 +64,15 @@ func (restAPIError *RestAPIError) Error() string {
 func (restAPIError *RestAPIError) GetType() ErrorType {
 	return RestAPIErrorType
 }
+
+// Is reports whether restAPIError matches a sentinel error, such as ErrNotFound,
+// so that RestAPIError can be checked with errors.Is.
+func (restAPIError *RestAPIError) Is(target error) bool {
+	if target == ErrPremiumRequired {
+		return restAPIError.StatusCode == http.StatusForbidden &&
+			strings.Contains(strings.ToLower(restAPIError.Message), "premium required")
+	}
+
+	sentinel := statusCodeSentinel(restAPIError.StatusCode)
+	return sentinel != nil && sentinel == target
+}
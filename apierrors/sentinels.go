@@ -0,0 +1,33 @@
+package apierrors
+
+import (
+	"errors"
+	"net/http"
+)
+
+// Sentinel errors that the errors returned by spotifygo match with errors.Is,
+// depending on their status codes and contents.
+var (
+	// ErrNotFound is matched by errors with the 404 status code.
+	ErrNotFound = errors.New("spotify: not found")
+	// ErrUnauthorized is matched by errors with the 401 status code and
+	// by authentication errors about invalid client credentials or grants.
+	ErrUnauthorized = errors.New("spotify: unauthorized")
+	// ErrForbidden is matched by errors with the 403 status code.
+	ErrForbidden = errors.New("spotify: forbidden")
+	// ErrRateLimited is matched by errors with the 429 status code.
+	ErrRateLimited = errors.New("spotify: rate limited")
+	// ErrPremiumRequired is matched by errors about actions that require
+	// a Spotify Premium subscription.
+	ErrPremiumRequired = errors.New("spotify: premium required")
+)
+
+// statusCodeSentinel returns the sentinel error matching a status code, or nil.
+func statusCodeSentinel(statusCode int) error {
+	return map[int]error{
+		http.StatusNotFound:        ErrNotFound,
+		http.StatusUnauthorized:    ErrUnauthorized,
+		http.StatusForbidden:       ErrForbidden,
+		http.StatusTooManyRequests: ErrRateLimited,
+	}[statusCode]
+}
@@ -1,3 +1,5 @@
 module github.com/taiypeo/spotifygo
 
 go 1.15
+
+require golang.org/x/oauth2 v0.0.0-20210819190943-2bc19b11175f
@@ -0,0 +1,16 @@
+package instrumentation
+
+import (
+	"net/http"
+
+	"github.com/taiypeo/spotifygo/requests"
+)
+
+// TokenEndpoint is the endpoint template of the accounts service token endpoint.
+const TokenEndpoint = requests.TokenEndpoint
+
+// EndpointTemplate returns the template of the endpoint that a request is sent to,
+// such as "albums/{id}" or "artists/{id}/top-tracks" (see requests.EndpointTemplate).
+func EndpointTemplate(request *http.Request) string {
+	return requests.EndpointTemplate(request)
+}
@@ -0,0 +1,137 @@
+package instrumentation
+
+import (
+	"context"
+	"io/ioutil"
+	"net/http"
+	"net/url"
+
+	"github.com/taiypeo/spotifygo"
+	"github.com/taiypeo/spotifygo/apierrors"
+	"github.com/taiypeo/spotifygo/requests"
+)
+
+// Tracer starts spans. It mirrors the subset of the OpenTelemetry trace.Tracer API
+// used by this package, so an OpenTelemetry tracer can be plugged in with a small adapter.
+type Tracer interface {
+	Start(ctx context.Context, spanName string) (context.Context, Span)
+}
+
+// Span is a span started by a Tracer.
+type Span interface {
+	SetAttribute(key string, value interface{})
+	RecordError(err error)
+	End()
+}
+
+// Middleware returns a requests.Middleware that creates a span per call with tracer
+// and reports the call to recorder. Either of them can be nil.
+// Spans are named after the method and the endpoint template (for example,
+// "GET albums/{id}") and carry the status code, the number of attempts and
+// whether the response was served from the cache.
+func Middleware(tracer Tracer, recorder Recorder) requests.Middleware {
+	return func(next requests.Handler) requests.Handler {
+		return func(request *http.Request) (spotifygo.APIResponse, apierrors.TypedError) {
+			endpoint := EndpointTemplate(request)
+
+			var span Span
+			if tracer != nil {
+				var ctx context.Context
+				ctx, span = tracer.Start(request.Context(), request.Method+" "+endpoint)
+				request = request.WithContext(ctx)
+				span.SetAttribute("http.method", request.Method)
+				span.SetAttribute("spotify.endpoint", endpoint)
+			}
+
+			response, err := next(request)
+
+			if span != nil {
+				span.SetAttribute("http.status_code", response.StatusCode)
+				span.SetAttribute("spotify.attempts", response.Attempts)
+				span.SetAttribute("spotify.from_cache", response.FromCache)
+				if err != nil {
+					span.RecordError(err)
+				}
+				span.End()
+			}
+
+			if recorder != nil {
+				recorder.ObserveRequest(
+					endpoint,
+					request.Method,
+					response.StatusCode,
+					response.Elapsed,
+				)
+				if endpoint == TokenEndpoint && err == nil && response.StatusCode == http.StatusOK {
+					recorder.ObserveTokenRequest(grantType(request))
+				}
+			}
+
+			return response, err
+		}
+	}
+}
+
+// grantType returns the grant_type form parameter of a token request.
+func grantType(request *http.Request) string {
+	if request.GetBody == nil {
+		return ""
+	}
+
+	body, err := request.GetBody()
+	if err != nil {
+		return ""
+	}
+	defer body.Close()
+
+	payload, err := ioutil.ReadAll(body)
+	if err != nil {
+		return ""
+	}
+
+	values, err := url.ParseQuery(string(payload))
+	if err != nil {
+		return ""
+	}
+
+	return values.Get("grant_type")
+}
+
+// rateLimitTransport reports every 429 response to a Recorder,
+// including the ones that are retried and never reach the middlewares.
+type rateLimitTransport struct {
+	next     http.RoundTripper
+	recorder Recorder
+}
+
+func (transport *rateLimitTransport) RoundTrip(request *http.Request) (*http.Response, error) {
+	response, err := transport.next.RoundTrip(request)
+	if err == nil && response.StatusCode == http.StatusTooManyRequests {
+		transport.recorder.ObserveRateLimited(EndpointTemplate(request))
+	}
+
+	return response, err
+}
+
+// Instrument registers Middleware(tracer, recorder) on the client and,
+// if recorder is not nil, wraps the transport of the client's HTTP client
+// (in a copy, so that shared HTTP clients are not modified) to count every 429 response.
+func Instrument(client *requests.Client, tracer Tracer, recorder Recorder) {
+	client.Use(Middleware(tracer, recorder))
+	if recorder == nil {
+		return
+	}
+
+	var httpClient http.Client
+	if client.HTTPClient != nil {
+		httpClient = *client.HTTPClient
+	}
+
+	next := httpClient.Transport
+	if next == nil {
+		next = http.DefaultTransport
+	}
+
+	httpClient.Transport = &rateLimitTransport{next: next, recorder: recorder}
+	client.HTTPClient = &httpClient
+}
@@ -0,0 +1,241 @@
+package instrumentation
+
+import (
+	"fmt"
+	"io"
+	"net/http"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+// Recorder receives the measurements of the requests made by an instrumented Client.
+// Implement it to feed an existing metrics system, or use Metrics.
+type Recorder interface {
+	// ObserveRequest is called once for every call, after all retries.
+	// statusCode is 0 if no response was received.
+	ObserveRequest(endpoint, method string, statusCode int, duration time.Duration)
+	// ObserveRateLimited is called for every 429 response, including retried ones.
+	ObserveRateLimited(endpoint string)
+	// ObserveTokenRequest is called for every successful token request,
+	// where grantType is for example "client_credentials" or "refresh_token".
+	ObserveTokenRequest(grantType string)
+}
+
+// DefaultBuckets are the default upper bounds (in seconds) of the
+// request duration histogram buckets.
+var DefaultBuckets = []float64{0.005, 0.01, 0.025, 0.05, 0.1, 0.25, 0.5, 1, 2.5, 5, 10}
+
+// Metrics is a Recorder that keeps Prometheus-style counters and histograms
+// in memory and exposes them in the Prometheus text format through ServeHTTP
+// (so it can be mounted as a /metrics handler) and WriteTo.
+// Metrics is safe for concurrent use.
+type Metrics struct {
+	mutex         sync.Mutex
+	buckets       []float64
+	requests      map[[3]string]int64
+	durations     map[[2]string]*histogram
+	rateLimited   map[string]int64
+	tokenRequests map[string]int64
+}
+
+type histogram struct {
+	counts []int64
+	sum    float64
+	count  int64
+}
+
+// NewMetrics creates a new Metrics with the given request duration histogram buckets
+// (DefaultBuckets if nil).
+func NewMetrics(buckets []float64) *Metrics {
+	if buckets == nil {
+		buckets = DefaultBuckets
+	}
+
+	sortedBuckets := append([]float64(nil), buckets...)
+	sort.Float64s(sortedBuckets)
+	return &Metrics{
+		buckets:       sortedBuckets,
+		requests:      make(map[[3]string]int64),
+		durations:     make(map[[2]string]*histogram),
+		rateLimited:   make(map[string]int64),
+		tokenRequests: make(map[string]int64),
+	}
+}
+
+// ObserveRequest implements Recorder.
+func (metrics *Metrics) ObserveRequest(
+	endpoint,
+	method string,
+	statusCode int,
+	duration time.Duration,
+) {
+	metrics.mutex.Lock()
+	defer metrics.mutex.Unlock()
+
+	metrics.requests[[3]string{endpoint, method, strconv.Itoa(statusCode)}]++
+
+	key := [2]string{endpoint, method}
+	durationHistogram, ok := metrics.durations[key]
+	if !ok {
+		durationHistogram = &histogram{counts: make([]int64, len(metrics.buckets))}
+		metrics.durations[key] = durationHistogram
+	}
+
+	seconds := duration.Seconds()
+	for i, bucket := range metrics.buckets {
+		if seconds <= bucket {
+			durationHistogram.counts[i]++
+		}
+	}
+	durationHistogram.sum += seconds
+	durationHistogram.count++
+}
+
+// ObserveRateLimited implements Recorder.
+func (metrics *Metrics) ObserveRateLimited(endpoint string) {
+	metrics.mutex.Lock()
+	defer metrics.mutex.Unlock()
+
+	metrics.rateLimited[endpoint]++
+}
+
+// ObserveTokenRequest implements Recorder.
+func (metrics *Metrics) ObserveTokenRequest(grantType string) {
+	metrics.mutex.Lock()
+	defer metrics.mutex.Unlock()
+
+	metrics.tokenRequests[grantType]++
+}
+
+func formatLabels(names []string, values []string) string {
+	pairs := make([]string, len(names))
+	for i, name := range names {
+		pairs[i] = fmt.Sprintf("%s=%q", name, values[i])
+	}
+
+	return "{" + strings.Join(pairs, ",") + "}"
+}
+
+func writeMetricHeader(builder *strings.Builder, name, help, metricType string) {
+	builder.WriteString("# HELP " + name + " " + help + "\n")
+	builder.WriteString("# TYPE " + name + " " + metricType + "\n")
+}
+
+func formatFloat(value float64) string {
+	return strconv.FormatFloat(value, 'g', -1, 64)
+}
+
+// WriteTo writes the metrics in the Prometheus text exposition format,
+// so Metrics implements io.WriterTo.
+func (metrics *Metrics) WriteTo(writer io.Writer) (int64, error) {
+	metrics.mutex.Lock()
+	var builder strings.Builder
+
+	writeMetricHeader(
+		&builder,
+		"spotify_requests_total",
+		"Spotify API calls by endpoint, method and status code.",
+		"counter",
+	)
+	var requestLines []string
+	for key, value := range metrics.requests {
+		requestLines = append(requestLines, fmt.Sprintf(
+			"spotify_requests_total%s %d\n",
+			formatLabels([]string{"endpoint", "method", "status"}, key[:]),
+			value,
+		))
+	}
+	sort.Strings(requestLines)
+	builder.WriteString(strings.Join(requestLines, ""))
+
+	writeMetricHeader(
+		&builder,
+		"spotify_request_duration_seconds",
+		"Spotify API call latency, including retries.",
+		"histogram",
+	)
+	var durationKeys [][2]string
+	for key := range metrics.durations {
+		durationKeys = append(durationKeys, key)
+	}
+	sort.Slice(durationKeys, func(i, j int) bool {
+		return durationKeys[i][0]+" "+durationKeys[i][1] < durationKeys[j][0]+" "+durationKeys[j][1]
+	})
+	for _, key := range durationKeys {
+		durationHistogram := metrics.durations[key]
+		for i, bucket := range metrics.buckets {
+			builder.WriteString(fmt.Sprintf(
+				"spotify_request_duration_seconds_bucket%s %d\n",
+				formatLabels(
+					[]string{"endpoint", "method", "le"},
+					[]string{key[0], key[1], formatFloat(bucket)},
+				),
+				durationHistogram.counts[i],
+			))
+		}
+		labels := formatLabels([]string{"endpoint", "method"}, key[:])
+		builder.WriteString(fmt.Sprintf(
+			"spotify_request_duration_seconds_bucket%s %d\n",
+			formatLabels([]string{"endpoint", "method", "le"}, []string{key[0], key[1], "+Inf"}),
+			durationHistogram.count,
+		))
+		builder.WriteString(fmt.Sprintf(
+			"spotify_request_duration_seconds_sum%s %s\n",
+			labels,
+			formatFloat(durationHistogram.sum),
+		))
+		builder.WriteString(fmt.Sprintf(
+			"spotify_request_duration_seconds_count%s %d\n",
+			labels,
+			durationHistogram.count,
+		))
+	}
+
+	writeMetricHeader(
+		&builder,
+		"spotify_rate_limited_total",
+		"Spotify API 429 responses, including retried ones.",
+		"counter",
+	)
+	var rateLimitedLines []string
+	for endpoint, value := range metrics.rateLimited {
+		rateLimitedLines = append(rateLimitedLines, fmt.Sprintf(
+			"spotify_rate_limited_total%s %d\n",
+			formatLabels([]string{"endpoint"}, []string{endpoint}),
+			value,
+		))
+	}
+	sort.Strings(rateLimitedLines)
+	builder.WriteString(strings.Join(rateLimitedLines, ""))
+
+	writeMetricHeader(
+		&builder,
+		"spotify_token_requests_total",
+		"Successful token requests by grant type.",
+		"counter",
+	)
+	var tokenLines []string
+	for grantType, value := range metrics.tokenRequests {
+		tokenLines = append(tokenLines, fmt.Sprintf(
+			"spotify_token_requests_total%s %d\n",
+			formatLabels([]string{"grant_type"}, []string{grantType}),
+			value,
+		))
+	}
+	sort.Strings(tokenLines)
+	builder.WriteString(strings.Join(tokenLines, ""))
+	metrics.mutex.Unlock()
+
+	written, err := io.WriteString(writer, builder.String())
+	return int64(written), err
+}
+
+// ServeHTTP writes the metrics in the Prometheus text exposition format,
+// so Metrics implements http.Handler.
+func (metrics *Metrics) ServeHTTP(writer http.ResponseWriter, request *http.Request) {
+	writer.Header().Set("Content-Type", "text/plain; version=0.0.4; charset=utf-8")
+	metrics.WriteTo(writer)
+}
@@ -0,0 +1,202 @@
+package recorder
+
+import (
+	"encoding/json"
+	"io/ioutil"
+	"net/http"
+	"net/url"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+
+	"github.com/taiypeo/spotifygo/apierrors"
+)
+
+// Redacted is the value that secrets are replaced with in recorded interactions.
+const Redacted = "REDACTED"
+
+// redactedHeaders are the headers whose values are never recorded.
+var redactedHeaders = []string{"Authorization", "Cookie", "Set-Cookie"}
+
+// redactedFields are the form fields and JSON fields whose values are never recorded.
+var redactedFields = []string{
+	"access_token",
+	"refresh_token",
+	"client_secret",
+	"code",
+	"code_verifier",
+}
+
+var redactedJSONFieldsRegexp = regexp.MustCompile(
+	`("(?:` + strings.Join(redactedFields, "|") + `)"\s*:\s*)"(?:[^"\\]|\\.)*"`,
+)
+
+// RecordedRequest is a recorded HTTP request with secrets redacted.
+type RecordedRequest struct {
+	Method string      `json:"method"`
+	URL    string      `json:"url"`
+	Header http.Header `json:"header"`
+	Body   string      `json:"body"`
+}
+
+// RecordedResponse is a recorded HTTP response with secrets redacted.
+type RecordedResponse struct {
+	StatusCode int         `json:"status_code"`
+	Header     http.Header `json:"header"`
+	Body       string      `json:"body"`
+}
+
+// Interaction is a recorded request/response pair.
+type Interaction struct {
+	Request  RecordedRequest  `json:"request"`
+	Response RecordedResponse `json:"response"`
+}
+
+func redactHeader(header http.Header) http.Header {
+	redacted := header.Clone()
+	for _, name := range redactedHeaders {
+		if redacted.Get(name) != "" {
+			redacted.Set(name, Redacted)
+		}
+	}
+
+	return redacted
+}
+
+// redactBody replaces the values of redactedFields in a JSON or
+// x-www-form-urlencoded body.
+func redactBody(body string) string {
+	trimmed := strings.TrimSpace(body)
+	if trimmed == "" || strings.HasPrefix(trimmed, "{") || strings.HasPrefix(trimmed, "[") {
+		return redactedJSONFieldsRegexp.ReplaceAllString(body, `$1"`+Redacted+`"`)
+	}
+
+	values, err := url.ParseQuery(body)
+	if err != nil {
+		return body
+	}
+
+	redacted := false
+	for _, field := range redactedFields {
+		if _, ok := values[field]; ok {
+			values.Set(field, Redacted)
+			redacted = true
+		}
+	}
+	if !redacted {
+		return body
+	}
+
+	return values.Encode()
+}
+
+// redactURL replaces the values of redactedFields in the query of a URL.
+func redactURL(rawURL string) string {
+	parsedURL, err := url.Parse(rawURL)
+	if err != nil {
+		return rawURL
+	}
+
+	query := parsedURL.Query()
+	redacted := false
+	for _, field := range redactedFields {
+		if _, ok := query[field]; ok {
+			query.Set(field, Redacted)
+			redacted = true
+		}
+	}
+	if !redacted {
+		return rawURL
+	}
+
+	parsedURL.RawQuery = query.Encode()
+	return parsedURL.String()
+}
+
+// readRequestBody returns the body of request without modifying the request.
+// The body is read from request.GetBody; requests without GetBody have their Body
+// consumed (which the http.RoundTripper contract allows), so they must not be sent again.
+func readRequestBody(request *http.Request) (string, apierrors.TypedError) {
+	body := request.Body
+	if request.GetBody != nil {
+		var err error
+		if body, err = request.GetBody(); err != nil {
+			return "", apierrors.NewBasicErrorFromError(err)
+		}
+		defer body.Close()
+	}
+	if body == nil || body == http.NoBody {
+		return "", nil
+	}
+
+	data, err := ioutil.ReadAll(body)
+	if err != nil {
+		return "", apierrors.NewBasicErrorFromError(err)
+	}
+
+	return string(data), nil
+}
+
+func newRecordedRequest(request *http.Request, body string) RecordedRequest {
+	return RecordedRequest{
+		Method: request.Method,
+		URL:    redactURL(request.URL.String()),
+		Header: redactHeader(request.Header),
+		Body:   redactBody(body),
+	}
+}
+
+// matches reports whether a recorded request matches another one,
+// which is the case if the methods, URLs and bodies are equal.
+func (recorded RecordedRequest) matches(other RecordedRequest) bool {
+	return recorded.Method == other.Method &&
+		recorded.URL == other.URL &&
+		recorded.Body == other.Body
+}
+
+// LoadInteractions reads the interactions recorded in a golden file.
+func LoadInteractions(path string) ([]Interaction, apierrors.TypedError) {
+	data, err := ioutil.ReadFile(path)
+	if err != nil {
+		return nil, apierrors.NewBasicErrorFromError(err)
+	}
+
+	var interactions []Interaction
+	if err := json.Unmarshal(data, &interactions); err != nil {
+		return nil, apierrors.NewBasicErrorFromError(err)
+	}
+
+	return interactions, nil
+}
+
+// SaveInteractions atomically writes interactions to a golden file.
+func SaveInteractions(path string, interactions []Interaction) apierrors.TypedError {
+	data, err := json.MarshalIndent(interactions, "", "  ")
+	if err != nil {
+		return apierrors.NewBasicErrorFromError(err)
+	}
+
+	file, err := ioutil.TempFile(filepath.Dir(path), ".tmp-"+filepath.Base(path))
+	if err != nil {
+		return apierrors.NewBasicErrorFromError(err)
+	}
+
+	_, writeErr := file.Write(append(data, '\n'))
+	closeErr := file.Close()
+	if writeErr != nil || closeErr != nil {
+		os.Remove(file.Name())
+		if writeErr != nil {
+			return apierrors.NewBasicErrorFromError(writeErr)
+		}
+
+		return apierrors.NewBasicErrorFromError(closeErr)
+	}
+
+	if err := os.Rename(file.Name(), path); err != nil {
+		os.Remove(file.Name())
+		return apierrors.NewBasicErrorFromError(err)
+	}
+
+	return nil
+}
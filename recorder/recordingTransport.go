@@ -0,0 +1,98 @@
+package recorder
+
+import (
+	"io/ioutil"
+	"net/http"
+	"strings"
+	"sync"
+
+	"github.com/taiypeo/spotifygo/apierrors"
+)
+
+// RecordingTransport is an http.RoundTripper that performs real requests
+// and records every request/response pair to a golden file, which can later
+// be served by a ReplayTransport. Authorization headers, tokens, codes and
+// client secrets are redacted before anything is written.
+// Use it as the Transport of the HTTP client of a requests.Client.
+type RecordingTransport struct {
+	mutex        sync.Mutex
+	path         string
+	next         http.RoundTripper
+	interactions []Interaction
+}
+
+// NewRecordingTransport creates a new RecordingTransport that writes to the golden file
+// at path (which is overwritten) and performs requests with next
+// (http.DefaultTransport if nil).
+func NewRecordingTransport(
+	path string,
+	next http.RoundTripper,
+) (*RecordingTransport, apierrors.TypedError) {
+	if next == nil {
+		next = http.DefaultTransport
+	}
+
+	transport := &RecordingTransport{path: path, next: next}
+	if err := SaveInteractions(path, []Interaction{}); err != nil {
+		return nil, err
+	}
+
+	return transport, nil
+}
+
+// RoundTrip performs the request and records it, so RecordingTransport
+// implements http.RoundTripper.
+func (transport *RecordingTransport) RoundTrip(request *http.Request) (*http.Response, error) {
+	requestBody, err := readRequestBody(request)
+	if err != nil {
+		if request.Body != nil {
+			request.Body.Close()
+		}
+		return nil, err
+	}
+	recordedRequest := newRecordedRequest(request, requestBody)
+
+	outgoingRequest := request
+	if request.GetBody == nil && request.Body != nil && request.Body != http.NoBody {
+		// The body was consumed by readRequestBody, so a copy with a new body is sent.
+		request.Body.Close()
+		outgoingRequest = request.Clone(request.Context())
+		outgoingRequest.Body = ioutil.NopCloser(strings.NewReader(requestBody))
+	}
+
+	response, roundTripErr := transport.next.RoundTrip(outgoingRequest)
+	if roundTripErr != nil {
+		return nil, roundTripErr
+	}
+
+	responseBody, readErr := ioutil.ReadAll(response.Body)
+	response.Body.Close()
+	if readErr != nil {
+		return nil, readErr
+	}
+	response.Body = ioutil.NopCloser(strings.NewReader(string(responseBody)))
+
+	// Redaction can change the length of the body, so Content-Length is not recorded.
+	responseHeader := redactHeader(response.Header)
+	responseHeader.Del("Content-Length")
+
+	interaction := Interaction{
+		Request: recordedRequest,
+		Response: RecordedResponse{
+			StatusCode: response.StatusCode,
+			Header:     responseHeader,
+			Body:       redactBody(string(responseBody)),
+		},
+	}
+
+	transport.mutex.Lock()
+	defer transport.mutex.Unlock()
+
+	transport.interactions = append(transport.interactions, interaction)
+	if err := SaveInteractions(transport.path, transport.interactions); err != nil {
+		response.Body.Close()
+		return nil, err
+	}
+
+	return response, nil
+}
@@ -0,0 +1,119 @@
+package recorder
+
+import (
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"strings"
+	"sync"
+
+	"github.com/taiypeo/spotifygo/apierrors"
+)
+
+// ReplayTransport is an http.RoundTripper that serves the interactions recorded
+// by a RecordingTransport without touching the network.
+// Requests are matched by method, URL and (redacted) body; identical requests
+// are answered in the order they were recorded, and every recorded interaction
+// is served at most once. A request without a matching interaction fails with
+// an error that describes it, and OnUnmatched (if not nil) is called with it
+// beforehand, so that tests can fail right away, for example with t.Errorf.
+// Since the error is a transport error, consider disabling the retries of
+// the requests.Client that uses the transport.
+type ReplayTransport struct {
+	OnUnmatched  func(request RecordedRequest)
+	mutex        sync.Mutex
+	interactions []Interaction
+	used         []bool
+}
+
+// NewReplayTransport creates a new ReplayTransport that serves
+// the interactions from the golden file at path.
+func NewReplayTransport(path string) (*ReplayTransport, apierrors.TypedError) {
+	interactions, err := LoadInteractions(path)
+	if err != nil {
+		return nil, err
+	}
+
+	return NewReplayTransportFromInteractions(interactions), nil
+}
+
+// NewReplayTransportFromInteractions creates a new ReplayTransport
+// that serves the given interactions.
+func NewReplayTransportFromInteractions(interactions []Interaction) *ReplayTransport {
+	return &ReplayTransport{
+		interactions: interactions,
+		used:         make([]bool, len(interactions)),
+	}
+}
+
+// RoundTrip serves the first unused interaction matching the request,
+// so ReplayTransport implements http.RoundTripper.
+func (transport *ReplayTransport) RoundTrip(request *http.Request) (*http.Response, error) {
+	if request.Body != nil {
+		defer request.Body.Close()
+	}
+
+	body, err := readRequestBody(request)
+	if err != nil {
+		return nil, err
+	}
+	recordedRequest := newRecordedRequest(request, body)
+
+	transport.mutex.Lock()
+	defer transport.mutex.Unlock()
+
+	for i, interaction := range transport.interactions {
+		if transport.used[i] || !interaction.Request.matches(recordedRequest) {
+			continue
+		}
+
+		transport.used[i] = true
+		header := interaction.Response.Header.Clone()
+		if header == nil {
+			header = http.Header{}
+		}
+
+		return &http.Response{
+			Status: fmt.Sprintf(
+				"%d %s",
+				interaction.Response.StatusCode,
+				http.StatusText(interaction.Response.StatusCode),
+			),
+			StatusCode:    interaction.Response.StatusCode,
+			Proto:         "HTTP/1.1",
+			ProtoMajor:    1,
+			ProtoMinor:    1,
+			Header:        header,
+			Body:          ioutil.NopCloser(strings.NewReader(interaction.Response.Body)),
+			ContentLength: int64(len(interaction.Response.Body)),
+			Request:       request,
+		}, nil
+	}
+
+	if transport.OnUnmatched != nil {
+		transport.OnUnmatched(recordedRequest)
+	}
+
+	return nil, apierrors.NewBasicErrorFromString(fmt.Sprintf(
+		"recorder: no unused recorded interaction matches %s %s with body %q",
+		recordedRequest.Method,
+		recordedRequest.URL,
+		recordedRequest.Body,
+	))
+}
+
+// Unused returns the recorded interactions that have not been served yet,
+// which lets tests check that every expected request was made.
+func (transport *ReplayTransport) Unused() []Interaction {
+	transport.mutex.Lock()
+	defer transport.mutex.Unlock()
+
+	var unused []Interaction
+	for i, interaction := range transport.interactions {
+		if !transport.used[i] {
+			unused = append(unused, interaction)
+		}
+	}
+
+	return unused
+}
@@ -0,0 +1,155 @@
+package requests
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/taiypeo/spotifygo"
+	"github.com/taiypeo/spotifygo/apierrors"
+)
+
+// CachedResponse is a response stored in a Cache.
+// ETag is the entity tag used for revalidation (may be empty);
+// StoredAt is the time the response was stored or last revalidated;
+// MaxAge is the time after StoredAt during which the response is fresh
+// and can be served without contacting the server.
+type CachedResponse struct {
+	StatusCode int           `json:"status_code"`
+	JSONBody   string        `json:"body"`
+	Header     http.Header   `json:"header"`
+	ETag       string        `json:"etag"`
+	StoredAt   time.Time     `json:"stored_at"`
+	MaxAge     time.Duration `json:"max_age"`
+}
+
+// Cache is a response cache used by Client for GET requests.
+// Keys already identify both the URL and the token that was used,
+// and they do not contain the token itself.
+// Implementations must be safe for concurrent use.
+type Cache interface {
+	Get(key string) (CachedResponse, bool)
+	Set(key string, response CachedResponse)
+	Delete(key string)
+}
+
+func (cached CachedResponse) fresh(now time.Time) bool {
+	return cached.MaxAge > 0 && now.Before(cached.StoredAt.Add(cached.MaxAge))
+}
+
+func (cached CachedResponse) apiResponse(httpMethod, url string) spotifygo.APIResponse {
+	return spotifygo.APIResponse{
+		StatusCode: cached.StatusCode,
+		JSONBody:   cached.JSONBody,
+		Header:     cached.Header,
+		Method:     httpMethod,
+		URL:        url,
+		FromCache:  true,
+	}
+}
+
+// cacheKey returns the key of a request to url authorized with the given
+// Authorization header. The token is hashed, so that it is not stored in the cache.
+func cacheKey(url, authorization string) string {
+	tokenHash := sha256.Sum256([]byte(authorization))
+	return url + " " + hex.EncodeToString(tokenHash[:])
+}
+
+// parseCacheControl returns the max-age of the Cache-Control header and
+// whether the response can be stored at all.
+func parseCacheControl(header http.Header) (time.Duration, bool) {
+	var maxAge time.Duration
+	for _, directive := range strings.Split(header.Get("Cache-Control"), ",") {
+		directive = strings.ToLower(strings.TrimSpace(directive))
+		switch {
+		case directive == "no-store":
+			return 0, false
+		case directive == "no-cache":
+			return 0, true
+		case strings.HasPrefix(directive, "max-age="):
+			seconds, err := strconv.ParseInt(strings.TrimPrefix(directive, "max-age="), 10, 64)
+			if err == nil && seconds > 0 {
+				maxAge = time.Duration(seconds) * time.Second
+			}
+		}
+	}
+
+	return maxAge, true
+}
+
+func newCachedResponse(response spotifygo.APIResponse, now time.Time) (CachedResponse, bool) {
+	maxAge, storable := parseCacheControl(response.Header)
+	etag := response.Header.Get("ETag")
+	if !storable || (maxAge == 0 && etag == "") {
+		return CachedResponse{}, false
+	}
+
+	return CachedResponse{
+		StatusCode: response.StatusCode,
+		JSONBody:   response.JSONBody,
+		Header:     response.Header,
+		ETag:       etag,
+		StoredAt:   now,
+		MaxAge:     maxAge,
+	}, true
+}
+
+// doWithCache serves GET requests from client.Cache when possible:
+// fresh responses are returned right away, stale ones are revalidated
+// with If-None-Match, and a 304 response is answered from the cache.
+func (client *Client) doWithCache(
+	request *http.Request,
+) (spotifygo.APIResponse, apierrors.TypedError) {
+	if client.Cache == nil || request.Method != http.MethodGet {
+		return client.doWithRetries(request)
+	}
+
+	start := time.Now()
+	url := request.URL.String()
+	key := cacheKey(url, request.Header.Get("Authorization"))
+	cached, found := client.Cache.Get(key)
+	if found && cached.fresh(start) {
+		response := cached.apiResponse(request.Method, url)
+		response.Elapsed = time.Since(start)
+		return response, nil
+	}
+
+	if found && cached.ETag != "" {
+		request = request.Clone(request.Context())
+		request.Header.Set("If-None-Match", cached.ETag)
+	}
+
+	response, err := client.doWithRetries(request)
+	if err != nil {
+		return response, err
+	}
+
+	now := time.Now()
+	switch {
+	case response.StatusCode == http.StatusNotModified && found:
+		maxAge, storable := parseCacheControl(response.Header)
+		if !storable {
+			client.Cache.Delete(key)
+		} else {
+			cached.StoredAt = now
+			cached.MaxAge = maxAge
+			client.Cache.Set(key, cached)
+		}
+
+		cachedResponse := cached.apiResponse(request.Method, response.URL)
+		cachedResponse.Elapsed = response.Elapsed
+		cachedResponse.Attempts = response.Attempts
+		return cachedResponse, nil
+	case response.StatusCode == http.StatusOK:
+		if newCached, ok := newCachedResponse(response, now); ok {
+			client.Cache.Set(key, newCached)
+		} else if found {
+			client.Cache.Delete(key)
+		}
+	}
+
+	return response, nil
+}
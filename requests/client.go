@@ -0,0 +1,100 @@
+package requests
+
+import (
+	"net/http"
+
+	"github.com/taiypeo/spotifygo/apierrors"
+)
+
+const (
+	// DefaultRestAPIBaseURL is the base URL of the Spotify REST API.
+	DefaultRestAPIBaseURL = "https://api.spotify.com/v1/"
+	// DefaultAccountsBaseURL is the base URL of the Spotify accounts service.
+	DefaultAccountsBaseURL = "https://accounts.spotify.com/"
+)
+
+// Client is a configurable requester for the Spotify REST API and
+// accounts service.
+// HTTPClient is the underlying HTTP client (http.DefaultClient if nil);
+// RestAPIBaseURL is the URL that REST API sub-URLs are resolved against
+// (DefaultRestAPIBaseURL if empty);
+// AccountsBaseURL is the URL that accounts service paths, such as api/token,
+// are resolved against (DefaultAccountsBaseURL if empty);
+// RetryPolicy describes how failed requests are retried (no retries if nil);
+// RateLimiter limits the rate of the requests (no limit if nil), and can be
+// shared between multiple Clients;
+// Cache stores GET responses and revalidates them with ETags (no caching if nil);
+// Middlewares wrap every request made by the client (see Use);
+// Logger receives a record for every request (no logging if nil),
+// in which tokens and client secrets are always redacted;
+// ClientAuthStyle is how the application credentials are sent to the token endpoint.
+type Client struct {
+	HTTPClient      *http.Client
+	RestAPIBaseURL  string
+	AccountsBaseURL string
+	RetryPolicy     *RetryPolicy
+	RateLimiter     *RateLimiter
+	Cache           Cache
+	Middlewares     []Middleware
+	Logger          Logger
+	ClientAuthStyle ClientAuthStyle
+}
+
+// DefaultClient is the Client used by the package-level functions,
+// such as GetRestAPI and PostAuthorization.
+var DefaultClient = NewClient()
+
+// NewClient creates a new Client with the default HTTP client and base URLs.
+// Retries are disabled; set RetryPolicy (for example, to DefaultRetryPolicy())
+// to enable them.
+func NewClient() *Client {
+	return &Client{
+		HTTPClient:      &http.Client{},
+		RestAPIBaseURL:  DefaultRestAPIBaseURL,
+		AccountsBaseURL: DefaultAccountsBaseURL,
+	}
+}
+
+// orDefault returns DefaultClient if client is nil, so that
+// a nil *Client can be passed everywhere a Client is expected.
+func (client *Client) orDefault() *Client {
+	if client == nil {
+		return DefaultClient
+	}
+
+	return client
+}
+
+func (client *Client) httpClient() *http.Client {
+	if client.HTTPClient == nil {
+		return http.DefaultClient
+	}
+
+	return client.HTTPClient
+}
+
+func (client *Client) restAPIBaseURL() string {
+	if client.RestAPIBaseURL == "" {
+		return DefaultRestAPIBaseURL
+	}
+
+	return client.RestAPIBaseURL
+}
+
+func (client *Client) accountsBaseURL() string {
+	if client.AccountsBaseURL == "" {
+		return DefaultAccountsBaseURL
+	}
+
+	return client.AccountsBaseURL
+}
+
+// GetTokenURL returns the URL of the accounts service token endpoint.
+func (client *Client) GetTokenURL() (string, apierrors.TypedError) {
+	return resolveURL(client.orDefault().accountsBaseURL(), "api/token")
+}
+
+// GetAuthorizeURL returns the URL of the accounts service authorize endpoint.
+func (client *Client) GetAuthorizeURL() (string, apierrors.TypedError) {
+	return resolveURL(client.orDefault().accountsBaseURL(), "authorize")
+}
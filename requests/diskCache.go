@@ -0,0 +1,81 @@
+package requests
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+
+	"github.com/taiypeo/spotifygo/apierrors"
+)
+
+// DiskCache is a Cache that stores every response as a JSON file
+// in a directory, so that cached responses survive process restarts.
+// Files are written atomically, so DiskCache can be shared by multiple processes.
+type DiskCache struct {
+	directory string
+}
+
+// NewDiskCache creates a new DiskCache in the given directory,
+// creating the directory if it does not exist.
+func NewDiskCache(directory string) (*DiskCache, apierrors.TypedError) {
+	if err := os.MkdirAll(directory, 0700); err != nil {
+		return nil, apierrors.NewBasicErrorFromError(err)
+	}
+
+	return &DiskCache{directory}, nil
+}
+
+func (cache *DiskCache) path(key string) string {
+	keyHash := sha256.Sum256([]byte(key))
+	return filepath.Join(cache.directory, hex.EncodeToString(keyHash[:])+".json")
+}
+
+// Get returns the response stored under key, so DiskCache implements Cache.
+// Unreadable files are treated as missing.
+func (cache *DiskCache) Get(key string) (CachedResponse, bool) {
+	data, err := ioutil.ReadFile(cache.path(key))
+	if err != nil {
+		return CachedResponse{}, false
+	}
+
+	var response CachedResponse
+	if err := json.Unmarshal(data, &response); err != nil {
+		cache.Delete(key)
+		return CachedResponse{}, false
+	}
+
+	return response, true
+}
+
+// Set stores a response under key, so DiskCache implements Cache.
+// Errors are ignored, as a failure to cache a response is not fatal.
+func (cache *DiskCache) Set(key string, response CachedResponse) {
+	data, err := json.Marshal(response)
+	if err != nil {
+		return
+	}
+
+	file, err := ioutil.TempFile(cache.directory, "tmp-")
+	if err != nil {
+		return
+	}
+
+	_, writeErr := file.Write(data)
+	closeErr := file.Close()
+	if writeErr != nil || closeErr != nil {
+		os.Remove(file.Name())
+		return
+	}
+
+	if err := os.Rename(file.Name(), cache.path(key)); err != nil {
+		os.Remove(file.Name())
+	}
+}
+
+// Delete removes the response stored under key, so DiskCache implements Cache.
+func (cache *DiskCache) Delete(key string) {
+	os.Remove(cache.path(key))
+}
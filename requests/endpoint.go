@@ -0,0 +1,68 @@
+package requests
+
+import (
+	"net/http"
+	"net/url"
+	"strings"
+)
+
+// TokenEndpoint is the endpoint template of the accounts service token endpoint.
+const TokenEndpoint = "token"
+
+// collections are the REST API path segments that are followed by an object ID.
+var collections = map[string]bool{
+	"albums":         true,
+	"artists":        true,
+	"audio-analysis": true,
+	"audio-features": true,
+	"audiobooks":     true,
+	"categories":     true,
+	"chapters":       true,
+	"episodes":       true,
+	"playlists":      true,
+	"shows":          true,
+	"tracks":         true,
+	"users":          true,
+}
+
+// EndpointTemplate returns the template of the endpoint that a request is sent to,
+// such as "albums/{id}" or "artists/{id}/top-tracks", with IDs replaced by "{id}"
+// and the query removed, so that it can be used as a low-cardinality label.
+// Requests to the accounts service token endpoint are reported as TokenEndpoint.
+func EndpointTemplate(request *http.Request) string {
+	return endpointTemplate(request.URL.Path)
+}
+
+// endpointTemplateFromURL is the same as EndpointTemplate, but takes the URL
+// of the request. Unparsable URLs result in an empty template.
+func endpointTemplateFromURL(rawURL string) string {
+	parsedURL, err := url.Parse(rawURL)
+	if err != nil {
+		return ""
+	}
+
+	return endpointTemplate(parsedURL.Path)
+}
+
+func endpointTemplate(path string) string {
+	if strings.HasSuffix(path, "/api/token") {
+		return TokenEndpoint
+	}
+
+	if index := strings.LastIndex(path, "/v1/"); index >= 0 {
+		path = path[index+len("/v1/"):]
+	}
+
+	segments := strings.Split(strings.Trim(path, "/"), "/")
+	for i := 1; i < len(segments); i++ {
+		// Collections of the current user, such as me/tracks, are followed
+		// by literal segments (as in me/tracks/contains), not by IDs.
+		underMe := i >= 2 && segments[i-2] == "me"
+		if collections[segments[i-1]] && !underMe {
+			segments[i] = "{id}"
+			i++
+		}
+	}
+
+	return strings.Join(segments, "/")
+}
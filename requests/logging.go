@@ -0,0 +1,125 @@
+package requests
+
+import (
+	"net/http"
+	"net/url"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/taiypeo/spotifygo"
+	"github.com/taiypeo/spotifygo/apierrors"
+)
+
+// Logger is a structured logger in the style of log/slog:
+// args are alternating keys and values. *slog.Logger implements Logger.
+type Logger interface {
+	Info(msg string, args ...interface{})
+	Warn(msg string, args ...interface{})
+	Error(msg string, args ...interface{})
+}
+
+const redacted = "REDACTED"
+
+// secretParameters are the query and form parameters whose values are never logged.
+var secretParameters = []string{
+	"access_token",
+	"refresh_token",
+	"client_secret",
+	"code",
+	"code_verifier",
+}
+
+// secretHeaders are the headers whose values are never logged.
+var secretHeaders = []string{"Authorization", "Cookie"}
+
+func redactValues(values url.Values) url.Values {
+	redactedValues := url.Values{}
+	for key, value := range values {
+		redactedValues[key] = value
+		if stringInSlice(key, secretParameters) {
+			redactedValues[key] = []string{redacted}
+		}
+	}
+
+	return redactedValues
+}
+
+// redactURL returns rawURL with the values of secret query parameters redacted.
+func redactURL(rawURL string) string {
+	parsedURL, err := url.Parse(rawURL)
+	if err != nil {
+		return redacted
+	}
+
+	if parsedURL.RawQuery != "" {
+		parsedURL.RawQuery = redactValues(parsedURL.Query()).Encode()
+	}
+	parsedURL.User = nil
+	return parsedURL.String()
+}
+
+// redactFormPayload returns an x-www-form-urlencoded payload with
+// the values of secret parameters redacted.
+func redactFormPayload(payload string) string {
+	values, err := url.ParseQuery(payload)
+	if err != nil {
+		return redacted
+	}
+
+	return redactValues(values).Encode()
+}
+
+// redactHeaders formats headers as "Name: value" pairs with secret values redacted.
+func redactHeaders(header http.Header) string {
+	lines := make([]string, 0, len(header))
+	for name, values := range header {
+		value := strings.Join(values, ", ")
+		if stringInSlice(http.CanonicalHeaderKey(name), secretHeaders) {
+			value = redacted
+		}
+		lines = append(lines, name+": "+value)
+	}
+
+	sort.Strings(lines)
+	return strings.Join(lines, "; ")
+}
+
+// logRequest emits one log record for a request made by makeBasicRequest.
+func (client *Client) logRequest(
+	request *http.Request,
+	payload string,
+	response spotifygo.APIResponse,
+	err apierrors.TypedError,
+) {
+	if client.Logger == nil {
+		return
+	}
+
+	retries := response.Attempts - 1
+	if retries < 0 {
+		retries = 0
+	}
+
+	args := []interface{}{
+		"method", request.Method,
+		"url", redactURL(request.URL.String()),
+		"status", response.StatusCode,
+		"latency", response.Elapsed.Round(time.Microsecond).String(),
+		"retries", retries,
+		"from_cache", response.FromCache,
+		"headers", redactHeaders(request.Header),
+	}
+	if request.Header.Get("Content-Type") == "application/x-www-form-urlencoded" {
+		args = append(args, "payload", redactFormPayload(payload))
+	}
+
+	switch {
+	case err == nil:
+		client.Logger.Info("spotify request", args...)
+	case response.StatusCode != 0:
+		client.Logger.Warn("spotify request failed", append(args, "error", err.Error())...)
+	default:
+		client.Logger.Error("spotify request failed", append(args, "error", err.Error())...)
+	}
+}
@@ -0,0 +1,87 @@
+package requests
+
+import (
+	"container/list"
+	"sync"
+
+	"github.com/taiypeo/spotifygo/apierrors"
+)
+
+// MemoryCache is an in-memory Cache that evicts
+// the least recently used responses when it is full.
+type MemoryCache struct {
+	mutex    sync.Mutex
+	capacity int
+	order    *list.List
+	elements map[string]*list.Element
+}
+
+type memoryCacheEntry struct {
+	key      string
+	response CachedResponse
+}
+
+// NewMemoryCache creates a new MemoryCache that holds up to capacity responses.
+func NewMemoryCache(capacity int) (*MemoryCache, apierrors.TypedError) {
+	if capacity < 1 {
+		return nil, apierrors.NewBasicErrorFromString("capacity has to be at least 1")
+	}
+
+	return &MemoryCache{
+		capacity: capacity,
+		order:    list.New(),
+		elements: make(map[string]*list.Element),
+	}, nil
+}
+
+// Get returns the response stored under key, so MemoryCache implements Cache.
+func (cache *MemoryCache) Get(key string) (CachedResponse, bool) {
+	cache.mutex.Lock()
+	defer cache.mutex.Unlock()
+
+	element, ok := cache.elements[key]
+	if !ok {
+		return CachedResponse{}, false
+	}
+
+	cache.order.MoveToFront(element)
+	return element.Value.(*memoryCacheEntry).response, true
+}
+
+// Set stores a response under key, so MemoryCache implements Cache.
+func (cache *MemoryCache) Set(key string, response CachedResponse) {
+	cache.mutex.Lock()
+	defer cache.mutex.Unlock()
+
+	if element, ok := cache.elements[key]; ok {
+		element.Value.(*memoryCacheEntry).response = response
+		cache.order.MoveToFront(element)
+		return
+	}
+
+	cache.elements[key] = cache.order.PushFront(&memoryCacheEntry{key, response})
+	for cache.order.Len() > cache.capacity {
+		oldest := cache.order.Back()
+		cache.order.Remove(oldest)
+		delete(cache.elements, oldest.Value.(*memoryCacheEntry).key)
+	}
+}
+
+// Delete removes the response stored under key, so MemoryCache implements Cache.
+func (cache *MemoryCache) Delete(key string) {
+	cache.mutex.Lock()
+	defer cache.mutex.Unlock()
+
+	if element, ok := cache.elements[key]; ok {
+		cache.order.Remove(element)
+		delete(cache.elements, key)
+	}
+}
+
+// Len returns the number of stored responses.
+func (cache *MemoryCache) Len() int {
+	cache.mutex.Lock()
+	defer cache.mutex.Unlock()
+
+	return cache.order.Len()
+}
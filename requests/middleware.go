@@ -0,0 +1,52 @@
+package requests
+
+import (
+	"net/http"
+
+	"github.com/taiypeo/spotifygo"
+	"github.com/taiypeo/spotifygo/apierrors"
+)
+
+// Handler performs an outgoing request to the Spotify REST API or accounts service
+// and returns the received APIResponse. The request body (if any) can be read
+// again through request.GetBody.
+type Handler func(request *http.Request) (spotifygo.APIResponse, apierrors.TypedError)
+
+// Middleware wraps a Handler, which allows it to inspect and modify the outgoing
+// request before calling next (or to answer the request without calling it at all)
+// and to inspect and modify the returned APIResponse and TypedError.
+// The TypedError includes the errors for unaccepted status codes,
+// such as RestAPIError and AuthenticationError.
+// Middlewares see every call made by a Client (both REST API and token requests)
+// once, before caching, rate limiting and retries are applied.
+type Middleware func(next Handler) Handler
+
+// Use registers middlewares on the client.
+// The first registered middleware is the outermost one.
+// Use is not safe to call concurrently with requests made by the client.
+func (client *Client) Use(middlewares ...Middleware) {
+	client.Middlewares = append(client.Middlewares, middlewares...)
+}
+
+// handler returns the Handler that performs the requests of the client,
+// wrapped in the registered middlewares. Responses with a status code
+// that is not in acceptedStatusCodes are turned into errors by createStatusCodeError
+// inside the middlewares, so that the middlewares see these errors too.
+func (client *Client) handler(
+	acceptedStatusCodes []int,
+	createStatusCodeError func(spotifygo.APIResponse) apierrors.TypedError,
+) Handler {
+	handler := Handler(func(request *http.Request) (spotifygo.APIResponse, apierrors.TypedError) {
+		response, err := client.doWithCache(request)
+		if err != nil {
+			return response, err
+		}
+
+		return response, checkStatusCode(response, acceptedStatusCodes, createStatusCodeError)
+	})
+	for i := len(client.Middlewares) - 1; i >= 0; i-- {
+		handler = client.Middlewares[i](handler)
+	}
+
+	return handler
+}
@@ -0,0 +1,149 @@
+package requests
+
+import (
+	"context"
+	"math"
+	"net/http"
+	"sync"
+	"time"
+
+	"github.com/taiypeo/spotifygo/apierrors"
+)
+
+const (
+	// rateLimiterDecreaseFactor is the factor that the rate is multiplied by after a 429.
+	rateLimiterDecreaseFactor = 0.5
+	// rateLimiterMinRateFraction is the lowest fraction of the configured rate
+	// that the rate can be decreased to.
+	rateLimiterMinRateFraction = 1.0 / 16
+	// rateLimiterIncreaseFraction is the fraction of the configured rate
+	// that the rate is increased by every rateLimiterIncreaseInterval without a 429.
+	rateLimiterIncreaseFraction = 0.1
+	rateLimiterIncreaseInterval = time.Second
+)
+
+// RateLimiter is a client-side token bucket rate limiter that is safe for
+// concurrent use. The same RateLimiter can be set on multiple Clients
+// (for example, clients used with different tokens of the same application)
+// to make them share the limit.
+// The rate is decreased multiplicatively after every 429 response and slowly
+// recovers to the configured rate afterwards; a Retry-After header on a 429
+// response also pauses the limiter for its duration.
+type RateLimiter struct {
+	mutex          sync.Mutex
+	maxRate        float64
+	rate           float64
+	burst          float64
+	tokens         float64
+	lastRefill     time.Time
+	lastAdjustment time.Time
+	pausedUntil    time.Time
+}
+
+// NewRateLimiter creates a new RateLimiter.
+// requestsPerSecond is the sustained number of requests per second;
+// burst is the maximum number of requests that can be made at once.
+func NewRateLimiter(requestsPerSecond float64, burst int) (*RateLimiter, apierrors.TypedError) {
+	if requestsPerSecond <= 0 {
+		return nil, apierrors.NewBasicErrorFromString("requestsPerSecond has to be positive")
+	}
+
+	if burst < 1 {
+		return nil, apierrors.NewBasicErrorFromString("burst has to be at least 1")
+	}
+
+	now := time.Now()
+	return &RateLimiter{
+		maxRate:        requestsPerSecond,
+		rate:           requestsPerSecond,
+		burst:          float64(burst),
+		tokens:         float64(burst),
+		lastRefill:     now,
+		lastAdjustment: now,
+	}, nil
+}
+
+// Rate returns the current rate of the limiter in requests per second.
+func (limiter *RateLimiter) Rate() float64 {
+	limiter.mutex.Lock()
+	defer limiter.mutex.Unlock()
+
+	return limiter.rate
+}
+
+// refill must be called with the mutex held.
+func (limiter *RateLimiter) refill(now time.Time) {
+	elapsed := now.Sub(limiter.lastRefill).Seconds()
+	if elapsed > 0 {
+		limiter.tokens = math.Min(limiter.burst, limiter.tokens+elapsed*limiter.rate)
+		limiter.lastRefill = now
+	}
+}
+
+// Wait blocks until a request is allowed to be made or ctx is done,
+// in which case a ContextError is returned.
+func (limiter *RateLimiter) Wait(ctx context.Context) apierrors.TypedError {
+	limiter.mutex.Lock()
+	now := time.Now()
+	limiter.refill(now)
+
+	// The token is reserved right away, so tokens can become negative
+	// and the following callers wait for their turn.
+	limiter.tokens--
+	var wait time.Duration
+	if limiter.tokens < 0 {
+		wait = time.Duration(-limiter.tokens / limiter.rate * float64(time.Second))
+	}
+	if pause := limiter.pausedUntil.Sub(now); pause > wait {
+		wait = pause
+	}
+	limiter.mutex.Unlock()
+
+	if wait <= 0 {
+		return nil
+	}
+
+	if err := sleepContext(ctx, wait); err != nil {
+		limiter.mutex.Lock()
+		limiter.tokens++
+		limiter.mutex.Unlock()
+
+		return err
+	}
+
+	return nil
+}
+
+// Observe adapts the rate of the limiter to a received response:
+// a 429 decreases the rate (and pauses the limiter for retryAfter, if positive),
+// while other responses let the rate recover to the configured one.
+func (limiter *RateLimiter) Observe(statusCode int, retryAfter time.Duration) {
+	limiter.mutex.Lock()
+	defer limiter.mutex.Unlock()
+
+	now := time.Now()
+	limiter.refill(now)
+
+	if statusCode == http.StatusTooManyRequests {
+		limiter.rate = math.Max(
+			limiter.rate*rateLimiterDecreaseFactor,
+			limiter.maxRate*rateLimiterMinRateFraction,
+		)
+		limiter.tokens = math.Min(limiter.tokens, 0)
+		limiter.lastAdjustment = now
+		if pausedUntil := now.Add(retryAfter); pausedUntil.After(limiter.pausedUntil) {
+			limiter.pausedUntil = pausedUntil
+		}
+
+		return
+	}
+
+	if limiter.rate < limiter.maxRate &&
+		now.Sub(limiter.lastAdjustment) >= rateLimiterIncreaseInterval {
+		limiter.rate = math.Min(
+			limiter.maxRate,
+			limiter.rate+limiter.maxRate*rateLimiterIncreaseFraction,
+		)
+		limiter.lastAdjustment = now
+	}
+}
@@ -1,18 +1,18 @@
 package requests
 
 import (
+	"context"
 	"fmt"
 	"io/ioutil"
 	"net/http"
 	"net/url"
 	"strings"
+	"time"
 
 	"github.com/taiypeo/spotifygo"
 	"github.com/taiypeo/spotifygo/apierrors"
 )
 
-var client = &http.Client{}
-
 func stringInSlice(str string, slice []string) bool {
 	for _, s := range slice {
 		if str == s {
@@ -37,9 +37,8 @@ func acceptedStatusCode(statusCode int, acceptedCodes []int) bool {
 	return false
 }
 
-func getFullRestAPIURL(subURL string) (string, apierrors.TypedError) {
-	const baseRestAPIURL = "https://api.spotify.com/v1/"
-	parsedBaseURL, err := url.Parse(baseRestAPIURL)
+func resolveURL(baseURL, subURL string) (string, apierrors.TypedError) {
+	parsedBaseURL, err := url.Parse(baseURL)
 	if err != nil {
 		return "", apierrors.NewBasicErrorFromError(err)
 	}
@@ -51,34 +50,43 @@ func getFullRestAPIURL(subURL string) (string, apierrors.TypedError) {
 
 	resolvedURL := parsedBaseURL.ResolveReference(parsedSubURL)
 	if resolvedURL == nil {
-		return "", apierrors.NewBasicErrorFromString("resolvedURL is nil in getFullURL")
+		return "", apierrors.NewBasicErrorFromString("resolvedURL is nil in resolveURL")
 	}
 
 	return resolvedURL.String(), nil
 }
 
-func makeBasicRequest(
+func (client *Client) getFullRestAPIURL(subURL string) (string, apierrors.TypedError) {
+	return resolveURL(client.restAPIBaseURL(), subURL)
+}
+
+// attemptResult is the outcome of a single HTTP request attempt.
+// networkError is true if err was caused by the transport
+// (and not by the request construction or the context).
+type attemptResult struct {
+	response     spotifygo.APIResponse
+	err          apierrors.TypedError
+	networkError bool
+}
+
+func newRequest(
+	ctx context.Context,
 	httpMethod,
 	url string,
 	headers map[string]string,
 	payload string,
-	acceptedStatusCodes []int,
-	createStatusCodeError func(spotifygo.APIResponse) apierrors.TypedError,
-) (spotifygo.APIResponse, apierrors.TypedError) {
-	if !stringInSlice(
-		httpMethod,
-		[]string{http.MethodGet, http.MethodPost, http.MethodPut, http.MethodDelete},
-	) {
-		return spotifygo.APIResponse{},
-			apierrors.NewBasicErrorFromString("Unsupported HTTP method")
-	}
-
-	request, err := http.NewRequest(http.MethodGet, url, nil)
+) (*http.Request, apierrors.TypedError) {
+	request, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
 	if httpMethod != http.MethodGet {
-		request, err = http.NewRequest(httpMethod, url, strings.NewReader(payload))
+		request, err = http.NewRequestWithContext(
+			ctx,
+			httpMethod,
+			url,
+			strings.NewReader(payload),
+		)
 	}
 	if err != nil {
-		return spotifygo.APIResponse{}, apierrors.NewBasicErrorFromError(err)
+		return nil, apierrors.NewBasicErrorFromError(err)
 	}
 
 	request.Header.Set("Accept", "application/json")
@@ -86,46 +94,141 @@ func makeBasicRequest(
 		request.Header.Set(key, value)
 	}
 
-	response, err := client.Do(request)
+	return request, nil
+}
+
+// cloneRequest returns a copy of request with a fresh body,
+// so that the same request can be sent more than once.
+func cloneRequest(request *http.Request) (*http.Request, apierrors.TypedError) {
+	clone := request.Clone(request.Context())
+	if request.GetBody != nil {
+		body, err := request.GetBody()
+		if err != nil {
+			return nil, apierrors.NewBasicErrorFromError(err)
+		}
+		clone.Body = body
+	}
+
+	return clone, nil
+}
+
+func (client *Client) doRequest(request *http.Request) attemptResult {
+	ctx := request.Context()
+	result := attemptResult{
+		response: spotifygo.APIResponse{Method: request.Method, URL: request.URL.String()},
+	}
+
+	response, err := client.httpClient().Do(request)
 	if err != nil {
-		return spotifygo.APIResponse{}, apierrors.NewBasicErrorFromError(err)
+		if ctxErr := ctx.Err(); ctxErr != nil {
+			result.err = apierrors.NewContextError(ctxErr)
+			return result
+		}
+
+		result.err = apierrors.NewBasicErrorFromError(err)
+		result.networkError = true
+		return result
 	}
 	defer response.Body.Close()
 
+	result.response.StatusCode = response.StatusCode
+	result.response.Header = response.Header
+	if response.Request != nil && response.Request.URL != nil {
+		result.response.URL = response.Request.URL.String()
+	}
+
 	// We can safely use ReadAll here because all the responses
 	// will be from the Spotify API, and are therefore guaranteed
 	// to not be too big.
 	body, err := ioutil.ReadAll(response.Body)
 	if err != nil {
-		return spotifygo.APIResponse{StatusCode: response.StatusCode},
-			apierrors.NewBasicErrorFromError(err)
+		if ctxErr := ctx.Err(); ctxErr != nil {
+			result.err = apierrors.NewContextError(ctxErr)
+			return result
+		}
+
+		result.err = apierrors.NewBasicErrorFromError(err)
+		result.networkError = true
+		return result
 	}
 
-	apiResponse := spotifygo.APIResponse{StatusCode: response.StatusCode, JSONBody: string(body)}
+	result.response.JSONBody = string(body)
+	return result
+}
 
-	if !acceptedStatusCode(apiResponse.StatusCode, acceptedStatusCodes) {
-		if createStatusCodeError == nil {
-			errorMessage := fmt.Sprintf(
-				"Got an unsupported status code in a request: %d",
-				apiResponse.StatusCode,
-			)
-			return apiResponse, apierrors.NewBasicErrorFromString(errorMessage)
-		}
+// checkStatusCode returns the error for a response whose status code is not
+// in acceptedStatusCodes, created by createStatusCodeError (a BasicError if it is nil).
+func checkStatusCode(
+	response spotifygo.APIResponse,
+	acceptedStatusCodes []int,
+	createStatusCodeError func(spotifygo.APIResponse) apierrors.TypedError,
+) apierrors.TypedError {
+	if acceptedStatusCode(response.StatusCode, acceptedStatusCodes) {
+		return nil
+	}
+
+	if createStatusCodeError == nil {
+		errorMessage := fmt.Sprintf(
+			"Got an unsupported status code in a request: %d",
+			response.StatusCode,
+		)
+		return apierrors.NewBasicErrorFromString(errorMessage)
+	}
+
+	return createStatusCodeError(response)
+}
+
+func (client *Client) makeBasicRequest(
+	ctx context.Context,
+	httpMethod,
+	url string,
+	headers map[string]string,
+	payload string,
+	acceptedStatusCodes []int,
+	createStatusCodeError func(spotifygo.APIResponse) apierrors.TypedError,
+) (spotifygo.APIResponse, apierrors.TypedError) {
+	if !stringInSlice(
+		httpMethod,
+		[]string{http.MethodGet, http.MethodPost, http.MethodPut, http.MethodDelete},
+	) {
+		return spotifygo.APIResponse{},
+			apierrors.NewBasicErrorFromString("Unsupported HTTP method")
+	}
 
-		return apiResponse, createStatusCodeError(apiResponse)
+	request, err := newRequest(ctx, httpMethod, url, headers, payload)
+	if err != nil {
+		return spotifygo.APIResponse{}, err
 	}
 
-	return apiResponse, nil
+	apiResponse, err := client.handler(acceptedStatusCodes, createStatusCodeError)(request)
+	client.logRequest(request, payload, apiResponse, err)
+	return apiResponse, err
 }
 
-func makeRestAPIRequest(
+// newRestAPIStatusCodeError creates the error for an unaccepted REST API status code:
+// a RateLimitError for 429 responses and a RestAPIError otherwise.
+func newRestAPIStatusCodeError(response spotifygo.APIResponse) apierrors.TypedError {
+	if response.StatusCode != http.StatusTooManyRequests {
+		return apierrors.NewRestAPIError(response)
+	}
+
+	retryAfter, _ := parseRetryAfter(response.Header, time.Now())
+	return apierrors.NewRateLimitError(
+		response,
+		retryAfter,
+		endpointTemplateFromURL(response.URL),
+	)
+}
+
+func (client *Client) makeRestAPIRequest(
+	ctx context.Context,
 	httpMethod,
 	subURL string,
 	headers map[string]string,
 	payloadJSON string,
 	acceptedStatusCodes []int,
 ) (spotifygo.APIResponse, apierrors.TypedError) {
-	url, err := getFullRestAPIURL(subURL)
+	url, err := client.getFullRestAPIURL(subURL)
 	if err != nil {
 		return spotifygo.APIResponse{}, err
 	}
@@ -135,73 +238,118 @@ func makeRestAPIRequest(
 		updatedHeaders[key] = value
 	}
 
-	return makeBasicRequest(
+	return client.makeBasicRequest(
+		ctx,
 		httpMethod,
 		url,
 		updatedHeaders,
 		payloadJSON,
 		acceptedStatusCodes,
-		apierrors.NewRestAPIError,
+		newRestAPIStatusCodeError,
 	)
 }
 
-// GetRestAPI performs an HTTP GET request to a given Spotify REST API URL
+// GetRestAPIWithContext performs an HTTP GET request to a given Spotify REST API URL
 // (identified by subURL (part after .../v1/)) with the given headers.
-func GetRestAPI(
+// If ctx is canceled or its deadline expires, a ContextError is returned.
+func (client *Client) GetRestAPIWithContext(
+	ctx context.Context,
 	subURL string,
 	headers map[string]string,
 	acceptedStatusCodes []int,
 ) (spotifygo.APIResponse, apierrors.TypedError) {
-	return makeRestAPIRequest(http.MethodGet, subURL, headers, "", acceptedStatusCodes)
+	return client.orDefault().makeRestAPIRequest(
+		ctx,
+		http.MethodGet,
+		subURL,
+		headers,
+		"",
+		acceptedStatusCodes,
+	)
 }
 
-// PostRestAPI performs an HTTP POST request to a given Spotify REST API URL
+// PostRestAPIWithContext performs an HTTP POST request to a given Spotify REST API URL
 // (identified by subURL (part after .../v1/)) with the given headers and JSON payload.
-func PostRestAPI(
+// If ctx is canceled or its deadline expires, a ContextError is returned.
+func (client *Client) PostRestAPIWithContext(
+	ctx context.Context,
 	subURL string,
 	headers map[string]string,
 	payloadJSON string,
 	acceptedStatusCodes []int,
 ) (spotifygo.APIResponse, apierrors.TypedError) {
-	return makeRestAPIRequest(http.MethodPost, subURL, headers, payloadJSON, acceptedStatusCodes)
+	return client.orDefault().makeRestAPIRequest(
+		ctx,
+		http.MethodPost,
+		subURL,
+		headers,
+		payloadJSON,
+		acceptedStatusCodes,
+	)
 }
 
-// PutRestAPI performs an HTTP PUT request to a given Spotify REST API URL
+// PutRestAPIWithContext performs an HTTP PUT request to a given Spotify REST API URL
 // (identified by subURL (part after .../v1/)) with the given headers and JSON payload.
-func PutRestAPI(
+// If ctx is canceled or its deadline expires, a ContextError is returned.
+func (client *Client) PutRestAPIWithContext(
+	ctx context.Context,
 	subURL string,
 	headers map[string]string,
 	payloadJSON string,
 	acceptedStatusCodes []int,
 ) (spotifygo.APIResponse, apierrors.TypedError) {
-	return makeRestAPIRequest(http.MethodPut, subURL, headers, payloadJSON, acceptedStatusCodes)
+	return client.orDefault().makeRestAPIRequest(
+		ctx,
+		http.MethodPut,
+		subURL,
+		headers,
+		payloadJSON,
+		acceptedStatusCodes,
+	)
 }
 
-// DeleteRestAPI performs an HTTP DELETE request to a given Spotify REST API URL
+// DeleteRestAPIWithContext performs an HTTP DELETE request to a given Spotify REST API URL
 // (identified by subURL (part after .../v1/)) with the given headers.
-func DeleteRestAPI(
+// If ctx is canceled or its deadline expires, a ContextError is returned.
+func (client *Client) DeleteRestAPIWithContext(
+	ctx context.Context,
 	subURL string,
 	headers map[string]string,
 	acceptedStatusCodes []int,
 ) (spotifygo.APIResponse, apierrors.TypedError) {
-	return makeRestAPIRequest(http.MethodDelete, subURL, headers, "", acceptedStatusCodes)
+	return client.orDefault().makeRestAPIRequest(
+		ctx,
+		http.MethodDelete,
+		subURL,
+		headers,
+		"",
+		acceptedStatusCodes,
+	)
 }
 
-// PostAuthorization performs an HTTP POST request to the Spotify token API URL
+// PostAuthorizationWithContext performs an HTTP POST request to the Spotify token API URL
 // to retrieve an authorization token. The used authorization flow is specified
 // by the headers and the x-www-form-urlencoded payload.
-func PostAuthorization(
+// If ctx is canceled or its deadline expires, a ContextError is returned.
+func (client *Client) PostAuthorizationWithContext(
+	ctx context.Context,
 	headers map[string]string,
 	payloadFormURLEncoded string,
 ) (spotifygo.APIResponse, apierrors.TypedError) {
-	const tokenAPIURL = "https://accounts.spotify.com/api/token"
+	client = client.orDefault()
+
+	tokenAPIURL, err := client.GetTokenURL()
+	if err != nil {
+		return spotifygo.APIResponse{}, err
+	}
 
 	updatedHeaders := map[string]string{"Content-Type": "application/x-www-form-urlencoded"}
 	for key, value := range headers {
 		updatedHeaders[key] = value
 	}
 
-	response, err := makeBasicRequest(
+	response, err := client.makeBasicRequest(
+		ctx,
 		http.MethodPost,
 		tokenAPIURL,
 		updatedHeaders,
@@ -215,3 +363,209 @@ func PostAuthorization(
 
 	return response, nil
 }
+
+// GetRestAPI is the same as GetRestAPIWithContext with context.Background().
+func (client *Client) GetRestAPI(
+	subURL string,
+	headers map[string]string,
+	acceptedStatusCodes []int,
+) (spotifygo.APIResponse, apierrors.TypedError) {
+	return client.GetRestAPIWithContext(
+		context.Background(),
+		subURL,
+		headers,
+		acceptedStatusCodes,
+	)
+}
+
+// PostRestAPI is the same as PostRestAPIWithContext with context.Background().
+func (client *Client) PostRestAPI(
+	subURL string,
+	headers map[string]string,
+	payloadJSON string,
+	acceptedStatusCodes []int,
+) (spotifygo.APIResponse, apierrors.TypedError) {
+	return client.PostRestAPIWithContext(
+		context.Background(),
+		subURL,
+		headers,
+		payloadJSON,
+		acceptedStatusCodes,
+	)
+}
+
+// PutRestAPI is the same as PutRestAPIWithContext with context.Background().
+func (client *Client) PutRestAPI(
+	subURL string,
+	headers map[string]string,
+	payloadJSON string,
+	acceptedStatusCodes []int,
+) (spotifygo.APIResponse, apierrors.TypedError) {
+	return client.PutRestAPIWithContext(
+		context.Background(),
+		subURL,
+		headers,
+		payloadJSON,
+		acceptedStatusCodes,
+	)
+}
+
+// DeleteRestAPI is the same as DeleteRestAPIWithContext with context.Background().
+func (client *Client) DeleteRestAPI(
+	subURL string,
+	headers map[string]string,
+	acceptedStatusCodes []int,
+) (spotifygo.APIResponse, apierrors.TypedError) {
+	return client.DeleteRestAPIWithContext(
+		context.Background(),
+		subURL,
+		headers,
+		acceptedStatusCodes,
+	)
+}
+
+// PostAuthorization is the same as PostAuthorizationWithContext with context.Background().
+func (client *Client) PostAuthorization(
+	headers map[string]string,
+	payloadFormURLEncoded string,
+) (spotifygo.APIResponse, apierrors.TypedError) {
+	return client.PostAuthorizationWithContext(
+		context.Background(),
+		headers,
+		payloadFormURLEncoded,
+	)
+}
+
+// GetRestAPIWithContext performs an HTTP GET request to a given Spotify REST API URL
+// (identified by subURL (part after .../v1/)) with the given headers
+// using DefaultClient.
+// If ctx is canceled or its deadline expires, a ContextError is returned.
+func GetRestAPIWithContext(
+	ctx context.Context,
+	subURL string,
+	headers map[string]string,
+	acceptedStatusCodes []int,
+) (spotifygo.APIResponse, apierrors.TypedError) {
+	return DefaultClient.GetRestAPIWithContext(ctx, subURL, headers, acceptedStatusCodes)
+}
+
+// PostRestAPIWithContext performs an HTTP POST request to a given Spotify REST API URL
+// (identified by subURL (part after .../v1/)) with the given headers and JSON payload
+// using DefaultClient.
+// If ctx is canceled or its deadline expires, a ContextError is returned.
+func PostRestAPIWithContext(
+	ctx context.Context,
+	subURL string,
+	headers map[string]string,
+	payloadJSON string,
+	acceptedStatusCodes []int,
+) (spotifygo.APIResponse, apierrors.TypedError) {
+	return DefaultClient.PostRestAPIWithContext(
+		ctx,
+		subURL,
+		headers,
+		payloadJSON,
+		acceptedStatusCodes,
+	)
+}
+
+// PutRestAPIWithContext performs an HTTP PUT request to a given Spotify REST API URL
+// (identified by subURL (part after .../v1/)) with the given headers and JSON payload
+// using DefaultClient.
+// If ctx is canceled or its deadline expires, a ContextError is returned.
+func PutRestAPIWithContext(
+	ctx context.Context,
+	subURL string,
+	headers map[string]string,
+	payloadJSON string,
+	acceptedStatusCodes []int,
+) (spotifygo.APIResponse, apierrors.TypedError) {
+	return DefaultClient.PutRestAPIWithContext(
+		ctx,
+		subURL,
+		headers,
+		payloadJSON,
+		acceptedStatusCodes,
+	)
+}
+
+// DeleteRestAPIWithContext performs an HTTP DELETE request to a given Spotify REST API URL
+// (identified by subURL (part after .../v1/)) with the given headers
+// using DefaultClient.
+// If ctx is canceled or its deadline expires, a ContextError is returned.
+func DeleteRestAPIWithContext(
+	ctx context.Context,
+	subURL string,
+	headers map[string]string,
+	acceptedStatusCodes []int,
+) (spotifygo.APIResponse, apierrors.TypedError) {
+	return DefaultClient.DeleteRestAPIWithContext(ctx, subURL, headers, acceptedStatusCodes)
+}
+
+// PostAuthorizationWithContext performs an HTTP POST request to the Spotify token API URL
+// to retrieve an authorization token using DefaultClient.
+// If ctx is canceled or its deadline expires, a ContextError is returned.
+func PostAuthorizationWithContext(
+	ctx context.Context,
+	headers map[string]string,
+	payloadFormURLEncoded string,
+) (spotifygo.APIResponse, apierrors.TypedError) {
+	return DefaultClient.PostAuthorizationWithContext(ctx, headers, payloadFormURLEncoded)
+}
+
+// GetRestAPI performs an HTTP GET request to a given Spotify REST API URL
+// (identified by subURL (part after .../v1/)) with the given headers
+// using DefaultClient.
+func GetRestAPI(
+	subURL string,
+	headers map[string]string,
+	acceptedStatusCodes []int,
+) (spotifygo.APIResponse, apierrors.TypedError) {
+	return DefaultClient.GetRestAPI(subURL, headers, acceptedStatusCodes)
+}
+
+// PostRestAPI performs an HTTP POST request to a given Spotify REST API URL
+// (identified by subURL (part after .../v1/)) with the given headers and JSON payload
+// using DefaultClient.
+func PostRestAPI(
+	subURL string,
+	headers map[string]string,
+	payloadJSON string,
+	acceptedStatusCodes []int,
+) (spotifygo.APIResponse, apierrors.TypedError) {
+	return DefaultClient.PostRestAPI(subURL, headers, payloadJSON, acceptedStatusCodes)
+}
+
+// PutRestAPI performs an HTTP PUT request to a given Spotify REST API URL
+// (identified by subURL (part after .../v1/)) with the given headers and JSON payload
+// using DefaultClient.
+func PutRestAPI(
+	subURL string,
+	headers map[string]string,
+	payloadJSON string,
+	acceptedStatusCodes []int,
+) (spotifygo.APIResponse, apierrors.TypedError) {
+	return DefaultClient.PutRestAPI(subURL, headers, payloadJSON, acceptedStatusCodes)
+}
+
+// DeleteRestAPI performs an HTTP DELETE request to a given Spotify REST API URL
+// (identified by subURL (part after .../v1/)) with the given headers
+// using DefaultClient.
+func DeleteRestAPI(
+	subURL string,
+	headers map[string]string,
+	acceptedStatusCodes []int,
+) (spotifygo.APIResponse, apierrors.TypedError) {
+	return DefaultClient.DeleteRestAPI(subURL, headers, acceptedStatusCodes)
+}
+
+// PostAuthorization performs an HTTP POST request to the Spotify token API URL
+// to retrieve an authorization token using DefaultClient.
+// The used authorization flow is specified by the headers and
+// the x-www-form-urlencoded payload.
+func PostAuthorization(
+	headers map[string]string,
+	payloadFormURLEncoded string,
+) (spotifygo.APIResponse, apierrors.TypedError) {
+	return DefaultClient.PostAuthorization(headers, payloadFormURLEncoded)
+}
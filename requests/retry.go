@@ -0,0 +1,187 @@
+package requests
+
+import (
+	"context"
+	"math/rand"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/taiypeo/spotifygo"
+	"github.com/taiypeo/spotifygo/apierrors"
+)
+
+// RetryPolicy describes how a Client retries failed requests.
+// Responses with the 429 status code are retried for every HTTP method, honoring the
+// Retry-After header; 5xx responses and transient network errors are only retried
+// for the methods in IdempotentMethods, using exponential backoff with jitter.
+// MaxAttempts is the total number of attempts, including the first one
+// (values below 2 disable retries);
+// MinBackoff is the backoff before the first retry, which doubles on every attempt;
+// MaxBackoff caps the backoff;
+// MaxWait is the longest time to wait before a single retry (0 means no limit):
+// backoffs are capped by it, and a Retry-After longer than MaxWait is not waited for,
+// so the 429 response is returned instead;
+// IdempotentMethods is the list of HTTP methods that are safe to repeat.
+type RetryPolicy struct {
+	MaxAttempts       int
+	MinBackoff        time.Duration
+	MaxBackoff        time.Duration
+	MaxWait           time.Duration
+	IdempotentMethods []string
+}
+
+// DefaultRetryPolicy returns a RetryPolicy that suits most applications.
+// Clients do not retry by default, so it has to be set explicitly.
+func DefaultRetryPolicy() *RetryPolicy {
+	return &RetryPolicy{
+		MaxAttempts: 4,
+		MinBackoff:  500 * time.Millisecond,
+		MaxBackoff:  8 * time.Second,
+		MaxWait:     time.Minute,
+		IdempotentMethods: []string{
+			http.MethodGet,
+			http.MethodPut,
+			http.MethodDelete,
+		},
+	}
+}
+
+// backoff returns the exponential backoff with jitter before the given retry
+// (retry 1 is the first retry). The result lies in [backoff / 2, backoff].
+func (policy *RetryPolicy) backoff(retry int) time.Duration {
+	backoff := policy.MinBackoff
+	for i := 1; i < retry && backoff < policy.MaxBackoff; i++ {
+		backoff *= 2
+	}
+	if policy.MaxBackoff > 0 && backoff > policy.MaxBackoff {
+		backoff = policy.MaxBackoff
+	}
+	if policy.MaxWait > 0 && backoff > policy.MaxWait {
+		backoff = policy.MaxWait
+	}
+	if backoff <= 0 {
+		return 0
+	}
+
+	half := backoff / 2
+	return half + time.Duration(rand.Int63n(int64(backoff-half)+1))
+}
+
+// retryDelay decides whether the result of the given attempt should be retried
+// and returns the time to wait before doing so.
+func (policy *RetryPolicy) retryDelay(
+	attempt int,
+	httpMethod string,
+	result attemptResult,
+) (time.Duration, bool) {
+	if attempt >= policy.MaxAttempts {
+		return 0, false
+	}
+
+	idempotent := stringInSlice(httpMethod, policy.IdempotentMethods)
+	switch {
+	case result.networkError:
+		if !idempotent {
+			return 0, false
+		}
+	case result.err != nil:
+		return 0, false
+	case result.response.StatusCode == http.StatusTooManyRequests:
+	case result.response.StatusCode >= 500 && idempotent:
+	default:
+		return 0, false
+	}
+
+	if retryAfter, ok := parseRetryAfter(result.response.Header, time.Now()); ok {
+		if policy.MaxWait > 0 && retryAfter > policy.MaxWait {
+			return 0, false
+		}
+
+		return retryAfter, true
+	}
+
+	return policy.backoff(attempt), true
+}
+
+// parseRetryAfter parses the Retry-After header, which is either
+// a number of seconds or an HTTP date.
+func parseRetryAfter(header http.Header, now time.Time) (time.Duration, bool) {
+	value := strings.TrimSpace(header.Get("Retry-After"))
+	if value == "" {
+		return 0, false
+	}
+
+	if seconds, err := strconv.ParseInt(value, 10, 64); err == nil {
+		if seconds < 0 {
+			return 0, false
+		}
+
+		return time.Duration(seconds) * time.Second, true
+	}
+
+	date, err := http.ParseTime(value)
+	if err != nil {
+		return 0, false
+	}
+
+	if wait := date.Sub(now); wait > 0 {
+		return wait, true
+	}
+
+	return 0, true
+}
+
+// sleepContext waits for the given duration or until ctx is done,
+// in which case a ContextError is returned.
+func sleepContext(ctx context.Context, duration time.Duration) apierrors.TypedError {
+	timer := time.NewTimer(duration)
+	defer timer.Stop()
+
+	select {
+	case <-timer.C:
+		return nil
+	case <-ctx.Done():
+		return apierrors.NewContextError(ctx.Err())
+	}
+}
+
+func (client *Client) doWithRetries(
+	request *http.Request,
+) (spotifygo.APIResponse, apierrors.TypedError) {
+	ctx := request.Context()
+	start := time.Now()
+	for attempt := 1; ; attempt++ {
+		if client.RateLimiter != nil {
+			if err := client.RateLimiter.Wait(ctx); err != nil {
+				return spotifygo.APIResponse{Method: request.Method, URL: request.URL.String()}, err
+			}
+		}
+
+		attemptRequest, err := cloneRequest(request)
+		if err != nil {
+			return spotifygo.APIResponse{}, err
+		}
+
+		result := client.doRequest(attemptRequest)
+		result.response.Elapsed = time.Since(start)
+		result.response.Attempts = attempt
+		if client.RateLimiter != nil && result.response.StatusCode != 0 {
+			retryAfter, _ := parseRetryAfter(result.response.Header, time.Now())
+			client.RateLimiter.Observe(result.response.StatusCode, retryAfter)
+		}
+		if client.RetryPolicy == nil {
+			return result.response, result.err
+		}
+
+		delay, retry := client.RetryPolicy.retryDelay(attempt, request.Method, result)
+		if !retry {
+			return result.response, result.err
+		}
+
+		if err := sleepContext(ctx, delay); err != nil {
+			return result.response, err
+		}
+	}
+}
@@ -0,0 +1,178 @@
+package requests
+
+import (
+	"context"
+	"errors"
+	"net/http"
+
+	"github.com/taiypeo/spotifygo"
+	"github.com/taiypeo/spotifygo/apierrors"
+)
+
+// expiredTokenMessage is the message of the 401 error that Spotify returns
+// for expired access tokens.
+const expiredTokenMessage = "The access token expired"
+
+// Token represents any type that can return a
+// Spotify authorization token, such as tokenauth.Token.
+type Token interface {
+	GetToken() string
+}
+
+// RefreshableToken is a Token that can be refreshed after Spotify
+// reports that its access token expired, such as tokenauth.TokenSource.
+// TokenWithContext must return the same value as GetToken, or the error
+// that prevented it from obtaining a valid token;
+// ForceRefresh must refresh the token unless it no longer returns staleToken
+// (a value previously returned by TokenWithContext or GetToken).
+type RefreshableToken interface {
+	Token
+	TokenWithContext(ctx context.Context) (string, apierrors.TypedError)
+	ForceRefresh(ctx context.Context, staleToken string) apierrors.TypedError
+}
+
+// isExpiredTokenError returns true if err is the REST API error
+// about an expired access token.
+func isExpiredTokenError(err apierrors.TypedError) bool {
+	var restAPIError *apierrors.RestAPIError
+	return errors.As(err, &restAPIError) &&
+		restAPIError.StatusCode == http.StatusUnauthorized &&
+		restAPIError.Message == expiredTokenMessage
+}
+
+// makeRestAPIRequestWithToken performs a REST API request authorized with the token.
+// If the token is a RefreshableToken, it is obtained with ctx (and its error is returned),
+// and if Spotify reports that it expired, the token is refreshed once and the request is replayed.
+func (client *Client) makeRestAPIRequestWithToken(
+	ctx context.Context,
+	httpMethod,
+	subURL string,
+	token Token,
+	payloadJSON string,
+	acceptedStatusCodes []int,
+) (spotifygo.APIResponse, apierrors.TypedError) {
+	refreshableToken, ok := token.(RefreshableToken)
+	if !ok {
+		return client.makeRestAPIRequest(
+			ctx,
+			httpMethod,
+			subURL,
+			map[string]string{"Authorization": token.GetToken()},
+			payloadJSON,
+			acceptedStatusCodes,
+		)
+	}
+
+	accessToken, err := refreshableToken.TokenWithContext(ctx)
+	if err != nil {
+		return spotifygo.APIResponse{}, err
+	}
+
+	response, err := client.makeRestAPIRequest(
+		ctx,
+		httpMethod,
+		subURL,
+		map[string]string{"Authorization": accessToken},
+		payloadJSON,
+		acceptedStatusCodes,
+	)
+	if !isExpiredTokenError(err) {
+		return response, err
+	}
+
+	if refreshErr := refreshableToken.ForceRefresh(ctx, accessToken); refreshErr != nil {
+		return response, refreshErr
+	}
+
+	accessToken, err = refreshableToken.TokenWithContext(ctx)
+	if err != nil {
+		return response, err
+	}
+
+	return client.makeRestAPIRequest(
+		ctx,
+		httpMethod,
+		subURL,
+		map[string]string{"Authorization": accessToken},
+		payloadJSON,
+		acceptedStatusCodes,
+	)
+}
+
+// GetRestAPIWithToken is the same as GetRestAPIWithContext, but authorizes
+// the request with the given token. If the token is a RefreshableToken and
+// its access token expired, it is refreshed and the request is replayed once.
+func (client *Client) GetRestAPIWithToken(
+	ctx context.Context,
+	subURL string,
+	token Token,
+	acceptedStatusCodes []int,
+) (spotifygo.APIResponse, apierrors.TypedError) {
+	return client.orDefault().makeRestAPIRequestWithToken(
+		ctx,
+		http.MethodGet,
+		subURL,
+		token,
+		"",
+		acceptedStatusCodes,
+	)
+}
+
+// PostRestAPIWithToken is the same as PostRestAPIWithContext, but authorizes
+// the request with the given token. If the token is a RefreshableToken and
+// its access token expired, it is refreshed and the request is replayed once.
+func (client *Client) PostRestAPIWithToken(
+	ctx context.Context,
+	subURL string,
+	token Token,
+	payloadJSON string,
+	acceptedStatusCodes []int,
+) (spotifygo.APIResponse, apierrors.TypedError) {
+	return client.orDefault().makeRestAPIRequestWithToken(
+		ctx,
+		http.MethodPost,
+		subURL,
+		token,
+		payloadJSON,
+		acceptedStatusCodes,
+	)
+}
+
+// PutRestAPIWithToken is the same as PutRestAPIWithContext, but authorizes
+// the request with the given token. If the token is a RefreshableToken and
+// its access token expired, it is refreshed and the request is replayed once.
+func (client *Client) PutRestAPIWithToken(
+	ctx context.Context,
+	subURL string,
+	token Token,
+	payloadJSON string,
+	acceptedStatusCodes []int,
+) (spotifygo.APIResponse, apierrors.TypedError) {
+	return client.orDefault().makeRestAPIRequestWithToken(
+		ctx,
+		http.MethodPut,
+		subURL,
+		token,
+		payloadJSON,
+		acceptedStatusCodes,
+	)
+}
+
+// DeleteRestAPIWithToken is the same as DeleteRestAPIWithContext, but authorizes
+// the request with the given token. If the token is a RefreshableToken and
+// its access token expired, it is refreshed and the request is replayed once.
+func (client *Client) DeleteRestAPIWithToken(
+	ctx context.Context,
+	subURL string,
+	token Token,
+	acceptedStatusCodes []int,
+) (spotifygo.APIResponse, apierrors.TypedError) {
+	return client.orDefault().makeRestAPIRequestWithToken(
+		ctx,
+		http.MethodDelete,
+		subURL,
+		token,
+		"",
+		acceptedStatusCodes,
+	)
+}
@@ -0,0 +1,63 @@
+package requests
+
+import (
+	"context"
+	"encoding/base64"
+	"net/url"
+
+	"github.com/taiypeo/spotifygo"
+	"github.com/taiypeo/spotifygo/apierrors"
+)
+
+// ClientAuthStyle represents how the application credentials are sent
+// to the token endpoint.
+type ClientAuthStyle int
+
+const (
+	// ClientAuthInHeader sends the credentials with HTTP Basic authentication.
+	ClientAuthInHeader ClientAuthStyle = iota
+	// ClientAuthInBody sends the credentials as client_id and client_secret
+	// form parameters.
+	ClientAuthInBody
+)
+
+// PostTokenWithContext performs an HTTP POST request to the Spotify token API URL
+// with the given form parameters and application credentials, which are sent
+// according to the ClientAuthStyle of the client.
+// If clientSecret is empty (public clients, such as in the PKCE flow),
+// only client_id is sent in the form.
+// If ctx is canceled or its deadline expires, a ContextError is returned.
+func (client *Client) PostTokenWithContext(
+	ctx context.Context,
+	form url.Values,
+	clientID,
+	clientSecret string,
+) (spotifygo.APIResponse, apierrors.TypedError) {
+	client = client.orDefault()
+
+	payload := url.Values{}
+	for key, values := range form {
+		payload[key] = values
+	}
+
+	headers := map[string]string{}
+	switch {
+	case clientSecret == "":
+		payload.Set("client_id", clientID)
+	case client.ClientAuthStyle == ClientAuthInHeader:
+		// RFC 6749 requires the credentials to be form-encoded before being
+		// joined and base64-encoded.
+		credentials := url.QueryEscape(clientID) + ":" + url.QueryEscape(clientSecret)
+		headers["Authorization"] = "Basic " +
+			base64.StdEncoding.EncodeToString([]byte(credentials))
+	case client.ClientAuthStyle == ClientAuthInBody:
+		payload.Set("client_id", clientID)
+		payload.Set("client_secret", clientSecret)
+	default:
+		return spotifygo.APIResponse{}, apierrors.NewBasicErrorFromString(
+			"Unknown client authentication style",
+		)
+	}
+
+	return client.PostAuthorizationWithContext(ctx, headers, payload.Encode())
+}
@@ -1,6 +1,7 @@
 package album
 
 import (
+	"context"
 	"encoding/json"
 
 	"github.com/taiypeo/spotifygo/apierrors"
@@ -17,6 +18,29 @@ func GetAlbum(
 	token tokenauth.Token,
 	albumID,
 	market string,
+) (apiobjects.FullAlbum, apierrors.TypedError) {
+	return GetAlbumWithClient(requests.DefaultClient, token, albumID, market)
+}
+
+// GetAlbumWithClient is the same as GetAlbum, but performs
+// the request using the given client.
+func GetAlbumWithClient(
+	client *requests.Client,
+	token tokenauth.Token,
+	albumID,
+	market string,
+) (apiobjects.FullAlbum, apierrors.TypedError) {
+	return GetAlbumWithContext(context.Background(), client, token, albumID, market)
+}
+
+// GetAlbumWithContext is the same as GetAlbumWithClient, but performs
+// the request with the given context. A nil client means requests.DefaultClient.
+func GetAlbumWithContext(
+	ctx context.Context,
+	client *requests.Client,
+	token tokenauth.Token,
+	albumID,
+	market string,
 ) (apiobjects.FullAlbum, apierrors.TypedError) {
 	url, typedErr := urltools.GetURLWithQueryParameters(
 		"albums/"+albumID,
@@ -26,9 +50,10 @@ func GetAlbum(
 		return apiobjects.FullAlbum{}, typedErr
 	}
 
-	response, typedErr := requests.GetRestAPI(
+	response, typedErr := client.GetRestAPIWithToken(
+		ctx,
 		url,
-		map[string]string{"Authorization": token.GetToken()},
+		token,
 		[]int{200},
 	)
 	if typedErr != nil {
@@ -1,6 +1,7 @@
 package album
 
 import (
+	"context"
 	"encoding/json"
 	"strconv"
 
@@ -21,6 +22,41 @@ func GetAlbumTracks(
 	limit,
 	offset int64,
 	market string,
+) (apiobjects.SimplifiedTrackPaging, apierrors.TypedError) {
+	return GetAlbumTracksWithClient(requests.DefaultClient, token, albumID, limit, offset, market)
+}
+
+// GetAlbumTracksWithClient is the same as GetAlbumTracks, but performs
+// the request using the given client.
+func GetAlbumTracksWithClient(
+	client *requests.Client,
+	token tokenauth.Token,
+	albumID string,
+	limit,
+	offset int64,
+	market string,
+) (apiobjects.SimplifiedTrackPaging, apierrors.TypedError) {
+	return GetAlbumTracksWithContext(
+		context.Background(),
+		client,
+		token,
+		albumID,
+		limit,
+		offset,
+		market,
+	)
+}
+
+// GetAlbumTracksWithContext is the same as GetAlbumTracksWithClient, but performs
+// the request with the given context. A nil client means requests.DefaultClient.
+func GetAlbumTracksWithContext(
+	ctx context.Context,
+	client *requests.Client,
+	token tokenauth.Token,
+	albumID string,
+	limit,
+	offset int64,
+	market string,
 ) (apiobjects.SimplifiedTrackPaging, apierrors.TypedError) {
 	if limit == 0 {
 		limit = 20 // default limit value, according to the docs
@@ -46,9 +82,10 @@ func GetAlbumTracks(
 		return apiobjects.SimplifiedTrackPaging{}, typedErr
 	}
 
-	response, typedErr := requests.GetRestAPI(
+	response, typedErr := client.GetRestAPIWithToken(
+		ctx,
 		url,
-		map[string]string{"Authorization": token.GetToken()},
+		token,
 		[]int{200},
 	)
 	if typedErr != nil {
@@ -1,6 +1,7 @@
 package album
 
 import (
+	"context"
 	"encoding/json"
 	"strings"
 
@@ -18,6 +19,29 @@ func GetAlbums(
 	token tokenauth.Token,
 	albumIDs []string,
 	market string,
+) ([]apiobjects.FullAlbum, apierrors.TypedError) {
+	return GetAlbumsWithClient(requests.DefaultClient, token, albumIDs, market)
+}
+
+// GetAlbumsWithClient is the same as GetAlbums, but performs
+// the request using the given client.
+func GetAlbumsWithClient(
+	client *requests.Client,
+	token tokenauth.Token,
+	albumIDs []string,
+	market string,
+) ([]apiobjects.FullAlbum, apierrors.TypedError) {
+	return GetAlbumsWithContext(context.Background(), client, token, albumIDs, market)
+}
+
+// GetAlbumsWithContext is the same as GetAlbumsWithClient, but performs
+// the request with the given context. A nil client means requests.DefaultClient.
+func GetAlbumsWithContext(
+	ctx context.Context,
+	client *requests.Client,
+	token tokenauth.Token,
+	albumIDs []string,
+	market string,
 ) ([]apiobjects.FullAlbum, apierrors.TypedError) {
 	if len(albumIDs) > 20 {
 		return nil, apierrors.NewBasicErrorFromString("albumIDs cannot be longer than 20")
@@ -33,9 +57,10 @@ func GetAlbums(
 		return nil, typedErr
 	}
 
-	response, typedErr := requests.GetRestAPI(
+	response, typedErr := client.GetRestAPIWithToken(
+		ctx,
 		url,
-		map[string]string{"Authorization": token.GetToken()},
+		token,
 		[]int{200},
 	)
 	if typedErr != nil {
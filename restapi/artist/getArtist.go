@@ -1,6 +1,7 @@
 package artist
 
 import (
+	"context"
 	"encoding/json"
 
 	"github.com/taiypeo/spotifygo/apierrors"
@@ -15,9 +16,31 @@ func GetArtist(
 	token tokenauth.Token,
 	artistID string,
 ) (apiobjects.FullArtist, apierrors.TypedError) {
-	response, typedErr := requests.GetRestAPI(
+	return GetArtistWithClient(requests.DefaultClient, token, artistID)
+}
+
+// GetArtistWithClient is the same as GetArtist, but performs
+// the request using the given client.
+func GetArtistWithClient(
+	client *requests.Client,
+	token tokenauth.Token,
+	artistID string,
+) (apiobjects.FullArtist, apierrors.TypedError) {
+	return GetArtistWithContext(context.Background(), client, token, artistID)
+}
+
+// GetArtistWithContext is the same as GetArtistWithClient, but performs
+// the request with the given context. A nil client means requests.DefaultClient.
+func GetArtistWithContext(
+	ctx context.Context,
+	client *requests.Client,
+	token tokenauth.Token,
+	artistID string,
+) (apiobjects.FullArtist, apierrors.TypedError) {
+	response, typedErr := client.GetRestAPIWithToken(
+		ctx,
 		"artists/"+artistID,
-		map[string]string{"Authorization": token.GetToken()},
+		token,
 		[]int{200},
 	)
 	if typedErr != nil {
@@ -1,6 +1,7 @@
 package artist
 
 import (
+	"context"
 	"encoding/json"
 	"strconv"
 	"strings"
@@ -57,6 +58,52 @@ func GetArtistAlbums(
 	country string,
 	limit,
 	offset int64,
+) (apiobjects.SimplifiedAlbumPaging, apierrors.TypedError) {
+	return GetArtistAlbumsWithClient(
+		requests.DefaultClient,
+		token,
+		artistID,
+		includeGroups,
+		country,
+		limit,
+		offset,
+	)
+}
+
+// GetArtistAlbumsWithClient is the same as GetArtistAlbums, but performs
+// the request using the given client.
+func GetArtistAlbumsWithClient(
+	client *requests.Client,
+	token tokenauth.Token,
+	artistID string,
+	includeGroups IncludeGroupType,
+	country string,
+	limit,
+	offset int64,
+) (apiobjects.SimplifiedAlbumPaging, apierrors.TypedError) {
+	return GetArtistAlbumsWithContext(
+		context.Background(),
+		client,
+		token,
+		artistID,
+		includeGroups,
+		country,
+		limit,
+		offset,
+	)
+}
+
+// GetArtistAlbumsWithContext is the same as GetArtistAlbumsWithClient, but performs
+// the request with the given context. A nil client means requests.DefaultClient.
+func GetArtistAlbumsWithContext(
+	ctx context.Context,
+	client *requests.Client,
+	token tokenauth.Token,
+	artistID string,
+	includeGroups IncludeGroupType,
+	country string,
+	limit,
+	offset int64,
 ) (apiobjects.SimplifiedAlbumPaging, apierrors.TypedError) {
 	if limit == 0 {
 		limit = 20 // default limit value, according to the docs
@@ -87,9 +134,10 @@ func GetArtistAlbums(
 		return apiobjects.SimplifiedAlbumPaging{}, typedErr
 	}
 
-	response, typedErr := requests.GetRestAPI(
+	response, typedErr := client.GetRestAPIWithToken(
+		ctx,
 		url,
-		map[string]string{"Authorization": token.GetToken()},
+		token,
 		[]int{200},
 	)
 	if typedErr != nil {
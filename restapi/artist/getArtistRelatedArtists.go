@@ -1,6 +1,7 @@
 package artist
 
 import (
+	"context"
 	"encoding/json"
 
 	"github.com/taiypeo/spotifygo/apierrors"
@@ -15,9 +16,31 @@ func GetArtistRelatedArtists(
 	token tokenauth.Token,
 	artistID string,
 ) ([]apiobjects.FullArtist, apierrors.TypedError) {
-	response, typedErr := requests.GetRestAPI(
+	return GetArtistRelatedArtistsWithClient(requests.DefaultClient, token, artistID)
+}
+
+// GetArtistRelatedArtistsWithClient is the same as GetArtistRelatedArtists, but performs
+// the request using the given client.
+func GetArtistRelatedArtistsWithClient(
+	client *requests.Client,
+	token tokenauth.Token,
+	artistID string,
+) ([]apiobjects.FullArtist, apierrors.TypedError) {
+	return GetArtistRelatedArtistsWithContext(context.Background(), client, token, artistID)
+}
+
+// GetArtistRelatedArtistsWithContext is the same as GetArtistRelatedArtistsWithClient, but performs
+// the request with the given context. A nil client means requests.DefaultClient.
+func GetArtistRelatedArtistsWithContext(
+	ctx context.Context,
+	client *requests.Client,
+	token tokenauth.Token,
+	artistID string,
+) ([]apiobjects.FullArtist, apierrors.TypedError) {
+	response, typedErr := client.GetRestAPIWithToken(
+		ctx,
 		"artists/"+artistID+"/related-artists",
-		map[string]string{"Authorization": token.GetToken()},
+		token,
 		[]int{200},
 	)
 	if typedErr != nil {
@@ -1,6 +1,7 @@
 package artist
 
 import (
+	"context"
 	"encoding/json"
 
 	"github.com/taiypeo/spotifygo/apierrors"
@@ -17,6 +18,29 @@ func GetArtistTopTracks(
 	token tokenauth.Token,
 	artistID string,
 	country string,
+) ([]apiobjects.FullTrack, apierrors.TypedError) {
+	return GetArtistTopTracksWithClient(requests.DefaultClient, token, artistID, country)
+}
+
+// GetArtistTopTracksWithClient is the same as GetArtistTopTracks, but performs
+// the request using the given client.
+func GetArtistTopTracksWithClient(
+	client *requests.Client,
+	token tokenauth.Token,
+	artistID string,
+	country string,
+) ([]apiobjects.FullTrack, apierrors.TypedError) {
+	return GetArtistTopTracksWithContext(context.Background(), client, token, artistID, country)
+}
+
+// GetArtistTopTracksWithContext is the same as GetArtistTopTracksWithClient, but performs
+// the request with the given context. A nil client means requests.DefaultClient.
+func GetArtistTopTracksWithContext(
+	ctx context.Context,
+	client *requests.Client,
+	token tokenauth.Token,
+	artistID string,
+	country string,
 ) ([]apiobjects.FullTrack, apierrors.TypedError) {
 	url, typedErr := urltools.GetURLWithQueryParameters(
 		"artists/"+artistID+"/top-tracks",
@@ -28,9 +52,10 @@ func GetArtistTopTracks(
 		return nil, typedErr
 	}
 
-	response, typedErr := requests.GetRestAPI(
+	response, typedErr := client.GetRestAPIWithToken(
+		ctx,
 		url,
-		map[string]string{"Authorization": token.GetToken()},
+		token,
 		[]int{200},
 	)
 	if typedErr != nil {
@@ -1,6 +1,7 @@
 package artist
 
 import (
+	"context"
 	"encoding/json"
 	"strings"
 
@@ -16,6 +17,27 @@ import (
 func GetArtists(
 	token tokenauth.Token,
 	artistIDs []string,
+) ([]apiobjects.FullArtist, apierrors.TypedError) {
+	return GetArtistsWithClient(requests.DefaultClient, token, artistIDs)
+}
+
+// GetArtistsWithClient is the same as GetArtists, but performs
+// the request using the given client.
+func GetArtistsWithClient(
+	client *requests.Client,
+	token tokenauth.Token,
+	artistIDs []string,
+) ([]apiobjects.FullArtist, apierrors.TypedError) {
+	return GetArtistsWithContext(context.Background(), client, token, artistIDs)
+}
+
+// GetArtistsWithContext is the same as GetArtistsWithClient, but performs
+// the request with the given context. A nil client means requests.DefaultClient.
+func GetArtistsWithContext(
+	ctx context.Context,
+	client *requests.Client,
+	token tokenauth.Token,
+	artistIDs []string,
 ) ([]apiobjects.FullArtist, apierrors.TypedError) {
 	if len(artistIDs) > 50 {
 		return nil, apierrors.NewBasicErrorFromString("artistIDs cannot be longer than 50")
@@ -30,9 +52,10 @@ func GetArtists(
 		return nil, typedErr
 	}
 
-	response, typedErr := requests.GetRestAPI(
+	response, typedErr := client.GetRestAPIWithToken(
+		ctx,
 		url,
-		map[string]string{"Authorization": token.GetToken()},
+		token,
 		[]int{200},
 	)
 	if typedErr != nil {
@@ -1,6 +1,7 @@
 package episode
 
 import (
+	"context"
 	"encoding/json"
 
 	"github.com/taiypeo/spotifygo/apierrors"
@@ -16,6 +17,29 @@ func GetEpisode(
 	token tokenauth.Token,
 	episodeID,
 	market string,
+) (apiobjects.FullEpisode, apierrors.TypedError) {
+	return GetEpisodeWithClient(requests.DefaultClient, token, episodeID, market)
+}
+
+// GetEpisodeWithClient is the same as GetEpisode, but performs
+// the request using the given client.
+func GetEpisodeWithClient(
+	client *requests.Client,
+	token tokenauth.Token,
+	episodeID,
+	market string,
+) (apiobjects.FullEpisode, apierrors.TypedError) {
+	return GetEpisodeWithContext(context.Background(), client, token, episodeID, market)
+}
+
+// GetEpisodeWithContext is the same as GetEpisodeWithClient, but performs
+// the request with the given context. A nil client means requests.DefaultClient.
+func GetEpisodeWithContext(
+	ctx context.Context,
+	client *requests.Client,
+	token tokenauth.Token,
+	episodeID,
+	market string,
 ) (apiobjects.FullEpisode, apierrors.TypedError) {
 	url, typedErr := urltools.GetURLWithQueryParameters(
 		"episodes/"+episodeID,
@@ -27,9 +51,10 @@ func GetEpisode(
 		return apiobjects.FullEpisode{}, typedErr
 	}
 
-	response, typedErr := requests.GetRestAPI(
+	response, typedErr := client.GetRestAPIWithToken(
+		ctx,
 		url,
-		map[string]string{"Authorization": token.GetToken()},
+		token,
 		[]int{200},
 	)
 	if typedErr != nil {
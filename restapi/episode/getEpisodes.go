@@ -1,6 +1,7 @@
 package episode
 
 import (
+	"context"
 	"encoding/json"
 	"strings"
 
@@ -17,6 +18,29 @@ func GetEpisodes(
 	token tokenauth.Token,
 	episodeIDs []string,
 	market string,
+) ([]apiobjects.FullEpisode, apierrors.TypedError) {
+	return GetEpisodesWithClient(requests.DefaultClient, token, episodeIDs, market)
+}
+
+// GetEpisodesWithClient is the same as GetEpisodes, but performs
+// the request using the given client.
+func GetEpisodesWithClient(
+	client *requests.Client,
+	token tokenauth.Token,
+	episodeIDs []string,
+	market string,
+) ([]apiobjects.FullEpisode, apierrors.TypedError) {
+	return GetEpisodesWithContext(context.Background(), client, token, episodeIDs, market)
+}
+
+// GetEpisodesWithContext is the same as GetEpisodesWithClient, but performs
+// the request with the given context. A nil client means requests.DefaultClient.
+func GetEpisodesWithContext(
+	ctx context.Context,
+	client *requests.Client,
+	token tokenauth.Token,
+	episodeIDs []string,
+	market string,
 ) ([]apiobjects.FullEpisode, apierrors.TypedError) {
 	if len(episodeIDs) > 50 {
 		return nil, apierrors.NewBasicErrorFromString(
@@ -35,9 +59,10 @@ func GetEpisodes(
 		return nil, typedErr
 	}
 
-	response, typedErr := requests.GetRestAPI(
+	response, typedErr := client.GetRestAPIWithToken(
+		ctx,
 		url,
-		map[string]string{"Authorization": token.GetToken()},
+		token,
 		[]int{200},
 	)
 	if typedErr != nil {
@@ -1,6 +1,7 @@
 package personalization
 
 import (
+	"context"
 	"encoding/json"
 	"strconv"
 
@@ -38,12 +39,18 @@ func (timeRange TimeRange) String() (string, apierrors.TypedError) {
 }
 
 func sendRequest(
+	ctx context.Context,
+	client *requests.Client,
 	token tokenauth.Token,
 	limit int64,
 	offset int64,
 	timeRange TimeRange,
 	personalizationType string,
 ) (spotifygo.APIResponse, apierrors.TypedError) {
+	if typedErr := tokenauth.CheckScopes(token, tokenauth.UserTopRead); typedErr != nil {
+		return spotifygo.APIResponse{}, typedErr
+	}
+
 	if limit == 0 {
 		limit = 20 // default limit value, according to the docs
 	} else if limit < 1 || limit > 50 {
@@ -73,9 +80,10 @@ func sendRequest(
 		return spotifygo.APIResponse{}, typedErr
 	}
 
-	response, typedErr := requests.GetRestAPI(
+	response, typedErr := client.GetRestAPIWithToken(
+		ctx,
 		url,
-		map[string]string{"Authorization": token.GetToken()},
+		token,
 		[]int{200},
 	)
 	if typedErr != nil {
@@ -88,13 +96,46 @@ func sendRequest(
 // GetUserTopArtists performs a GET request to /me/{type} to receive
 // the current user's top artists.
 // The default value for limit is 0, this will set limit to 20, with accordance to the docs.
+// The token needs the user-top-read scope.
 func GetUserTopArtists(
 	token tokenauth.Token,
 	limit int64,
 	offset int64,
 	timeRange TimeRange,
 ) (apiobjects.FullArtistPaging, apierrors.TypedError) {
-	response, typedErr := sendRequest(token, limit, offset, timeRange, "artists")
+	return GetUserTopArtistsWithClient(requests.DefaultClient, token, limit, offset, timeRange)
+}
+
+// GetUserTopArtistsWithClient is the same as GetUserTopArtists, but performs
+// the request using the given client.
+func GetUserTopArtistsWithClient(
+	client *requests.Client,
+	token tokenauth.Token,
+	limit int64,
+	offset int64,
+	timeRange TimeRange,
+) (apiobjects.FullArtistPaging, apierrors.TypedError) {
+	return GetUserTopArtistsWithContext(
+		context.Background(),
+		client,
+		token,
+		limit,
+		offset,
+		timeRange,
+	)
+}
+
+// GetUserTopArtistsWithContext is the same as GetUserTopArtistsWithClient, but performs
+// the request with the given context. A nil client means requests.DefaultClient.
+func GetUserTopArtistsWithContext(
+	ctx context.Context,
+	client *requests.Client,
+	token tokenauth.Token,
+	limit int64,
+	offset int64,
+	timeRange TimeRange,
+) (apiobjects.FullArtistPaging, apierrors.TypedError) {
+	response, typedErr := sendRequest(ctx, client, token, limit, offset, timeRange, "artists")
 	if typedErr != nil {
 		return apiobjects.FullArtistPaging{}, typedErr
 	}
@@ -114,13 +155,46 @@ func GetUserTopArtists(
 // GetUserTopTracks performs a GET request to /me/{type} to receive
 // the current user's top tracks.
 // The default value for limit is 0, this will set limit to 20, with accordance to the docs.
+// The token needs the user-top-read scope.
 func GetUserTopTracks(
 	token tokenauth.Token,
 	limit int64,
 	offset int64,
 	timeRange TimeRange,
 ) (apiobjects.FullTrackPaging, apierrors.TypedError) {
-	response, typedErr := sendRequest(token, limit, offset, timeRange, "tracks")
+	return GetUserTopTracksWithClient(requests.DefaultClient, token, limit, offset, timeRange)
+}
+
+// GetUserTopTracksWithClient is the same as GetUserTopTracks, but performs
+// the request using the given client.
+func GetUserTopTracksWithClient(
+	client *requests.Client,
+	token tokenauth.Token,
+	limit int64,
+	offset int64,
+	timeRange TimeRange,
+) (apiobjects.FullTrackPaging, apierrors.TypedError) {
+	return GetUserTopTracksWithContext(
+		context.Background(),
+		client,
+		token,
+		limit,
+		offset,
+		timeRange,
+	)
+}
+
+// GetUserTopTracksWithContext is the same as GetUserTopTracksWithClient, but performs
+// the request with the given context. A nil client means requests.DefaultClient.
+func GetUserTopTracksWithContext(
+	ctx context.Context,
+	client *requests.Client,
+	token tokenauth.Token,
+	limit int64,
+	offset int64,
+	timeRange TimeRange,
+) (apiobjects.FullTrackPaging, apierrors.TypedError) {
+	response, typedErr := sendRequest(ctx, client, token, limit, offset, timeRange, "tracks")
 	if typedErr != nil {
 		return apiobjects.FullTrackPaging{}, typedErr
 	}
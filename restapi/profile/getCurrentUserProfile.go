@@ -1,6 +1,7 @@
 package profile
 
 import (
+	"context"
 	"encoding/json"
 
 	"github.com/taiypeo/spotifygo/apierrors"
@@ -11,12 +12,34 @@ import (
 
 // GetCurrentUserProfile performs a GET request to /me to receive
 // the current user's private user profile.
+// Email is only returned if the token has the user-read-email scope, while
+// Country and Product require the user-read-private scope.
 func GetCurrentUserProfile(
 	token tokenauth.Token,
 ) (apiobjects.PrivateUser, apierrors.TypedError) {
-	response, typedErr := requests.GetRestAPI(
+	return GetCurrentUserProfileWithClient(requests.DefaultClient, token)
+}
+
+// GetCurrentUserProfileWithClient is the same as GetCurrentUserProfile, but performs
+// the request using the given client.
+func GetCurrentUserProfileWithClient(
+	client *requests.Client,
+	token tokenauth.Token,
+) (apiobjects.PrivateUser, apierrors.TypedError) {
+	return GetCurrentUserProfileWithContext(context.Background(), client, token)
+}
+
+// GetCurrentUserProfileWithContext is the same as GetCurrentUserProfileWithClient, but performs
+// the request with the given context. A nil client means requests.DefaultClient.
+func GetCurrentUserProfileWithContext(
+	ctx context.Context,
+	client *requests.Client,
+	token tokenauth.Token,
+) (apiobjects.PrivateUser, apierrors.TypedError) {
+	response, typedErr := client.GetRestAPIWithToken(
+		ctx,
 		"me/",
-		map[string]string{"Authorization": token.GetToken()},
+		token,
 		[]int{200},
 	)
 	if typedErr != nil {
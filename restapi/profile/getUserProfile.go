@@ -1,6 +1,7 @@
 package profile
 
 import (
+	"context"
 	"encoding/json"
 
 	"github.com/taiypeo/spotifygo/apierrors"
@@ -15,9 +16,31 @@ func GetUserProfile(
 	token tokenauth.Token,
 	userID string,
 ) (apiobjects.PublicUser, apierrors.TypedError) {
-	response, typedErr := requests.GetRestAPI(
+	return GetUserProfileWithClient(requests.DefaultClient, token, userID)
+}
+
+// GetUserProfileWithClient is the same as GetUserProfile, but performs
+// the request using the given client.
+func GetUserProfileWithClient(
+	client *requests.Client,
+	token tokenauth.Token,
+	userID string,
+) (apiobjects.PublicUser, apierrors.TypedError) {
+	return GetUserProfileWithContext(context.Background(), client, token, userID)
+}
+
+// GetUserProfileWithContext is the same as GetUserProfileWithClient, but performs
+// the request with the given context. A nil client means requests.DefaultClient.
+func GetUserProfileWithContext(
+	ctx context.Context,
+	client *requests.Client,
+	token tokenauth.Token,
+	userID string,
+) (apiobjects.PublicUser, apierrors.TypedError) {
+	response, typedErr := client.GetRestAPIWithToken(
+		ctx,
 		"users/"+userID,
-		map[string]string{"Authorization": token.GetToken()},
+		token,
 		[]int{200},
 	)
 	if typedErr != nil {
@@ -0,0 +1,263 @@
+package spotifytest
+
+import (
+	"crypto/sha256"
+	"crypto/subtle"
+	"encoding/base64"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+)
+
+// authorizationCode is what an authorization code issued by /authorize can be
+// exchanged for.
+type authorizationCode struct {
+	grant
+	redirectURI         string
+	codeChallenge       string
+	codeChallengeMethod string
+}
+
+func equalStrings(a, b string) bool {
+	return subtle.ConstantTimeCompare([]byte(a), []byte(b)) == 1
+}
+
+// serveAuthorize emulates the user approving (or, if AuthorizingUserID is empty,
+// denying) the authorization request, and redirects right away.
+func (server *Server) serveAuthorize(writer http.ResponseWriter, request *http.Request) {
+	query := request.URL.Query()
+	clientID := query.Get("client_id")
+	responseType := query.Get("response_type")
+
+	server.mutex.Lock()
+	defer server.mutex.Unlock()
+
+	if _, ok := server.apps[clientID]; !ok {
+		http.Error(writer, "INVALID_CLIENT: Invalid client", http.StatusBadRequest)
+		return
+	}
+
+	redirectURL, err := url.Parse(query.Get("redirect_uri"))
+	if err != nil || query.Get("redirect_uri") == "" {
+		http.Error(writer, "INVALID_CLIENT: Invalid redirect URI", http.StatusBadRequest)
+		return
+	}
+
+	if responseType != "code" && responseType != "token" {
+		http.Error(writer, "unsupported_response_type", http.StatusBadRequest)
+		return
+	}
+
+	result := url.Values{}
+	if state := query.Get("state"); state != "" {
+		result.Set("state", state)
+	}
+
+	var scopes []string
+	if scope := query.Get("scope"); scope != "" {
+		scopes = strings.Split(scope, " ")
+	}
+	userGrant := grant{clientID: clientID, userID: server.AuthorizingUserID, scopes: scopes}
+
+	switch {
+	case server.AuthorizingUserID == "":
+		result.Set("error", "access_denied")
+	case responseType == "code":
+		code := randomString()
+		server.codes[code] = &authorizationCode{
+			grant:               userGrant,
+			redirectURI:         query.Get("redirect_uri"),
+			codeChallenge:       query.Get("code_challenge"),
+			codeChallengeMethod: query.Get("code_challenge_method"),
+		}
+		result.Set("code", code)
+	default:
+		result.Set("access_token", server.issueAccessToken(&userGrant))
+		result.Set("token_type", "Bearer")
+		result.Set("expires_in", strconv.FormatInt(int64(server.TokenLifetime.Seconds()), 10))
+		// Like Spotify, the granted scopes are not included in the fragment.
+	}
+
+	// Like Spotify, errors of the implicit grant flow are sent in the query.
+	if responseType == "token" && result.Get("error") == "" {
+		redirectURL.Fragment = result.Encode()
+	} else {
+		redirectQuery := redirectURL.Query()
+		for key, values := range result {
+			redirectQuery[key] = values
+		}
+		redirectURL.RawQuery = redirectQuery.Encode()
+	}
+
+	http.Redirect(writer, request, redirectURL.String(), http.StatusFound)
+}
+
+// clientCredentials returns the client ID and secret from the Authorization header
+// or, if it is missing, from the form.
+// As RFC 6749 requires, the credentials in the header are form-encoded.
+func clientCredentials(request *http.Request) (string, string, bool) {
+	if encodedID, encodedSecret, ok := request.BasicAuth(); ok {
+		clientID, idErr := url.QueryUnescape(encodedID)
+		clientSecret, secretErr := url.QueryUnescape(encodedSecret)
+		if idErr != nil || secretErr != nil {
+			return "", "", false
+		}
+
+		return clientID, clientSecret, true
+	}
+
+	_, hasSecret := request.PostForm["client_secret"]
+	return request.PostForm.Get("client_id"), request.PostForm.Get("client_secret"), hasSecret
+}
+
+func verifyCodeChallenge(code *authorizationCode, codeVerifier string) bool {
+	if code.codeChallengeMethod == "plain" {
+		return equalStrings(code.codeChallenge, codeVerifier)
+	}
+
+	verifierHash := sha256.Sum256([]byte(codeVerifier))
+	return equalStrings(
+		code.codeChallenge,
+		base64.RawURLEncoding.EncodeToString(verifierHash[:]),
+	)
+}
+
+func (server *Server) serveToken(writer http.ResponseWriter, request *http.Request) {
+	if request.Method != http.MethodPost {
+		writeAuthenticationError(writer, http.StatusMethodNotAllowed, "invalid_request", "")
+		return
+	}
+
+	if err := request.ParseForm(); err != nil {
+		writeAuthenticationError(writer, http.StatusBadRequest, "invalid_request", err.Error())
+		return
+	}
+
+	clientID, clientSecret, confidential := clientCredentials(request)
+
+	server.mutex.Lock()
+	defer server.mutex.Unlock()
+
+	expectedSecret, ok := server.apps[clientID]
+	if !ok || (confidential && !equalStrings(expectedSecret, clientSecret)) {
+		writeAuthenticationError(writer, http.StatusBadRequest, "invalid_client", "Invalid client")
+		return
+	}
+
+	var tokenGrant *grant
+	switch grantType := request.PostForm.Get("grant_type"); grantType {
+	case "client_credentials":
+		if !confidential {
+			writeAuthenticationError(
+				writer,
+				http.StatusBadRequest,
+				"invalid_client",
+				"Invalid client secret",
+			)
+			return
+		}
+
+		tokenGrant = &grant{clientID: clientID}
+	case "authorization_code":
+		code, ok := server.codes[request.PostForm.Get("code")]
+		if !ok || code.clientID != clientID {
+			writeAuthenticationError(
+				writer,
+				http.StatusBadRequest,
+				"invalid_grant",
+				"Invalid authorization code",
+			)
+			return
+		}
+		delete(server.codes, request.PostForm.Get("code"))
+
+		if code.redirectURI != request.PostForm.Get("redirect_uri") {
+			writeAuthenticationError(
+				writer,
+				http.StatusBadRequest,
+				"invalid_grant",
+				"Invalid redirect URI",
+			)
+			return
+		}
+
+		if code.codeChallenge != "" {
+			if !verifyCodeChallenge(code, request.PostForm.Get("code_verifier")) {
+				writeAuthenticationError(
+					writer,
+					http.StatusBadRequest,
+					"invalid_grant",
+					"code_verifier was incorrect",
+				)
+				return
+			}
+		} else if !confidential {
+			writeAuthenticationError(
+				writer,
+				http.StatusBadRequest,
+				"invalid_client",
+				"Invalid client secret",
+			)
+			return
+		}
+
+		tokenGrant = &code.grant
+		tokenGrant.pkce = code.codeChallenge != ""
+	case "refresh_token":
+		refreshGrant, ok := server.refreshTokens[request.PostForm.Get("refresh_token")]
+		if !ok || refreshGrant.clientID != clientID {
+			writeAuthenticationError(
+				writer,
+				http.StatusBadRequest,
+				"invalid_grant",
+				"Invalid refresh token",
+			)
+			return
+		}
+
+		if !refreshGrant.pkce && !confidential {
+			writeAuthenticationError(
+				writer,
+				http.StatusBadRequest,
+				"invalid_client",
+				"Invalid client secret",
+			)
+			return
+		}
+
+		tokenGrant = refreshGrant
+		if refreshGrant.pkce {
+			// Refresh tokens obtained with PKCE are rotated on every use.
+			delete(server.refreshTokens, request.PostForm.Get("refresh_token"))
+		}
+	default:
+		writeAuthenticationError(
+			writer,
+			http.StatusBadRequest,
+			"unsupported_grant_type",
+			"grant_type parameter is missing or unsupported",
+		)
+		return
+	}
+
+	response := map[string]interface{}{
+		"access_token": server.issueAccessToken(tokenGrant),
+		"token_type":   "Bearer",
+		"expires_in":   int64(server.TokenLifetime.Seconds()),
+	}
+
+	if tokenGrant.userID != "" {
+		response["scope"] = strings.Join(tokenGrant.scopes, " ")
+
+		grantType := request.PostForm.Get("grant_type")
+		if grantType == "authorization_code" || tokenGrant.pkce {
+			refreshToken := randomString()
+			refreshGrant := *tokenGrant
+			server.refreshTokens[refreshToken] = &refreshGrant
+			response["refresh_token"] = refreshToken
+		}
+	}
+
+	writeJSON(writer, http.StatusOK, response)
+}
@@ -0,0 +1,131 @@
+package spotifytest_test
+
+import (
+	"context"
+	"net/http"
+	"testing"
+
+	"github.com/taiypeo/spotifygo/requests"
+	"github.com/taiypeo/spotifygo/spotifytest"
+	"github.com/taiypeo/spotifygo/tokenauth"
+)
+
+const (
+	testClientID     = "client+id/1="
+	testClientSecret = "s+c/r=t:&%"
+	testRedirectURI  = "http://127.0.0.1:8080/callback?next=/home&lang=en+US"
+)
+
+var clientAuthStyles = map[string]requests.ClientAuthStyle{
+	"ClientAuthInHeader": requests.ClientAuthInHeader,
+	"ClientAuthInBody":   requests.ClientAuthInBody,
+}
+
+// authorize goes through the /authorize endpoint of the server
+// and returns the URL the user is redirected to.
+func authorize(t *testing.T, client *requests.Client, authorizationURL string) string {
+	t.Helper()
+
+	httpClient := *client.HTTPClient
+	httpClient.CheckRedirect = func(*http.Request, []*http.Request) error {
+		return http.ErrUseLastResponse
+	}
+
+	response, err := httpClient.Get(authorizationURL)
+	if err != nil {
+		t.Fatalf("cannot authorize: %v", err)
+	}
+	response.Body.Close()
+
+	return response.Header.Get("Location")
+}
+
+func TestClientCredentialsWithSpecialCharacters(t *testing.T) {
+	for name, style := range clientAuthStyles {
+		style := style
+		t.Run(name, func(t *testing.T) {
+			server := spotifytest.NewServer(spotifytest.NewCatalog())
+			defer server.Close()
+			server.RegisterApp(testClientID, testClientSecret)
+
+			client := server.Client()
+			client.ClientAuthStyle = style
+
+			if _, err := tokenauth.NewAuthTokenWithClient(
+				client,
+				testClientID,
+				testClientSecret,
+			); err != nil {
+				t.Errorf("cannot get a token: %v", err)
+			}
+
+			if _, err := tokenauth.NewAuthTokenWithClient(
+				client,
+				testClientID,
+				testClientSecret+"x",
+			); err == nil {
+				t.Error("got a token with a wrong client secret")
+			}
+		})
+	}
+}
+
+func TestAuthorizationCodeWithSpecialCharacters(t *testing.T) {
+	for name, style := range clientAuthStyles {
+		style := style
+		t.Run(name, func(t *testing.T) {
+			server := spotifytest.NewServer(spotifytest.NewCatalog())
+			defer server.Close()
+			server.RegisterApp(testClientID, testClientSecret)
+			server.AuthorizingUserID = "user"
+
+			client := server.Client()
+			client.ClientAuthStyle = style
+
+			state, err := tokenauth.NewState()
+			if err != nil {
+				t.Fatalf("cannot generate a state: %v", err)
+			}
+			authorizationURL, err := tokenauth.GetAuthorizationCodeURL(
+				client,
+				testClientID,
+				testRedirectURI,
+				state,
+				[]tokenauth.Scope{tokenauth.UserTopRead},
+				false,
+			)
+			if err != nil {
+				t.Fatalf("cannot build the authorization URL: %v", err)
+			}
+
+			code, err := tokenauth.ParseAuthorizationCallback(
+				authorize(t, client, authorizationURL),
+				state,
+			)
+			if err != nil {
+				t.Fatalf("cannot parse the callback: %v", err)
+			}
+
+			token, err := tokenauth.NewRefreshableAuthTokenWithContext(
+				context.Background(),
+				client,
+				code,
+				testRedirectURI,
+				testClientID,
+				testClientSecret,
+			)
+			if err != nil {
+				t.Fatalf("cannot exchange the code: %v", err)
+			}
+
+			if err := token.RefreshWithContext(
+				context.Background(),
+				client,
+				testClientID,
+				testClientSecret,
+			); err != nil {
+				t.Errorf("cannot refresh the token: %v", err)
+			}
+		})
+	}
+}
@@ -0,0 +1,531 @@
+package spotifytest
+
+import (
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/taiypeo/spotifygo/apiobjects"
+)
+
+// errorResponse is an error object that a handler returns instead of a response.
+type errorResponse struct {
+	statusCode int
+	message    string
+}
+
+func newErrorResponse(statusCode int, message string) *errorResponse {
+	return &errorResponse{statusCode, message}
+}
+
+var (
+	errNotFound      = newErrorResponse(http.StatusNotFound, "non existing id")
+	errNoService     = newErrorResponse(http.StatusNotFound, "Service not found")
+	errInvalidLimit  = newErrorResponse(http.StatusBadRequest, "Invalid limit")
+	errInvalidOffset = newErrorResponse(http.StatusBadRequest, "Invalid offset")
+	errTooManyIDs    = newErrorResponse(http.StatusBadRequest, "Too many ids requested")
+	errUserRequired  = newErrorResponse(
+		http.StatusUnauthorized,
+		"This request requires user authentication.",
+	)
+	errInsufficientScope = newErrorResponse(http.StatusForbidden, "Insufficient client scope")
+)
+
+// apiRequest is a REST API request authorized with a valid access token.
+type apiRequest struct {
+	query   url.Values
+	grant   grant
+	path    string
+	baseURL string
+}
+
+func (request apiRequest) hasScope(scope string) bool {
+	for _, grantedScope := range request.grant.scopes {
+		if grantedScope == scope {
+			return true
+		}
+	}
+
+	return false
+}
+
+// market returns the market query parameter (named name), resolving "from_token"
+// to the country of the user.
+func (server *Server) market(request apiRequest, name string) string {
+	market := request.query.Get(name)
+	if market == "from_token" {
+		return server.catalog.Users[request.grant.userID].Country
+	}
+
+	return market
+}
+
+// page returns the bounds of the requested page of total items and its paging object.
+func (request apiRequest) page(total int) (int, int, apiobjects.BasicPaging, *errorResponse) {
+	limit, offset := 20, 0
+	if value := request.query.Get("limit"); value != "" {
+		parsedLimit, err := strconv.Atoi(value)
+		if err != nil || parsedLimit < 1 || parsedLimit > 50 {
+			return 0, 0, apiobjects.BasicPaging{}, errInvalidLimit
+		}
+		limit = parsedLimit
+	}
+
+	if value := request.query.Get("offset"); value != "" {
+		parsedOffset, err := strconv.Atoi(value)
+		if err != nil || parsedOffset < 0 {
+			return 0, 0, apiobjects.BasicPaging{}, errInvalidOffset
+		}
+		offset = parsedOffset
+	}
+
+	pageURL := func(pageOffset int) string {
+		query := url.Values{}
+		for key, values := range request.query {
+			query[key] = values
+		}
+		query.Set("offset", strconv.Itoa(pageOffset))
+		query.Set("limit", strconv.Itoa(limit))
+		return request.baseURL + request.path + "?" + query.Encode()
+	}
+
+	paging := apiobjects.BasicPaging{
+		Href:   pageURL(offset),
+		Limit:  int64(limit),
+		Offset: int64(offset),
+		Total:  int64(total),
+	}
+	if offset+limit < total {
+		paging.Next = pageURL(offset + limit)
+	}
+	if offset > 0 {
+		previousOffset := offset - limit
+		if previousOffset < 0 {
+			previousOffset = 0
+		}
+		paging.Previous = pageURL(previousOffset)
+	}
+
+	start, end := offset, offset+limit
+	if start > total {
+		start = total
+	}
+	if end > total {
+		end = total
+	}
+
+	return start, end, paging, nil
+}
+
+func (request apiRequest) ids(maxIDs int) ([]string, *errorResponse) {
+	ids := strings.Split(request.query.Get("ids"), ",")
+	if len(ids) > maxIDs {
+		return nil, errTooManyIDs
+	}
+
+	return ids, nil
+}
+
+// authorize returns the grant of the access token in the Authorization header.
+func (server *Server) authorize(request *http.Request) (grant, *errorResponse) {
+	authorization := request.Header.Get("Authorization")
+	if authorization == "" {
+		return grant{}, newErrorResponse(http.StatusUnauthorized, "No token provided")
+	}
+
+	if !strings.HasPrefix(authorization, "Bearer ") {
+		return grant{}, newErrorResponse(
+			http.StatusBadRequest,
+			"Only valid bearer authentication supported",
+		)
+	}
+
+	server.mutex.Lock()
+	defer server.mutex.Unlock()
+
+	accessGrant, ok := server.accessTokens[strings.TrimPrefix(authorization, "Bearer ")]
+	if !ok {
+		return grant{}, newErrorResponse(http.StatusUnauthorized, "Invalid access token")
+	}
+
+	if !time.Now().Before(accessGrant.expiresAt) {
+		return grant{}, newErrorResponse(http.StatusUnauthorized, "The access token expired")
+	}
+
+	return *accessGrant, nil
+}
+
+func (server *Server) serveRestAPI(
+	writer http.ResponseWriter,
+	httpRequest *http.Request,
+	subURL string,
+) {
+	accessGrant, errResponse := server.authorize(httpRequest)
+	if errResponse != nil {
+		writeRestAPIError(writer, errResponse.statusCode, errResponse.message)
+		return
+	}
+
+	if httpRequest.Method != http.MethodGet {
+		writeRestAPIError(writer, http.StatusMethodNotAllowed, "Method not allowed")
+		return
+	}
+
+	request := apiRequest{
+		query:   httpRequest.URL.Query(),
+		grant:   accessGrant,
+		path:    subURL,
+		baseURL: server.URL() + "/v1/",
+	}
+
+	var response interface{}
+	segments := strings.Split(subURL, "/")
+	switch {
+	case len(segments) == 1 && segments[0] == "albums":
+		response, errResponse = server.getAlbums(request)
+	case len(segments) == 2 && segments[0] == "albums":
+		response, errResponse = server.getAlbum(request, segments[1])
+	case len(segments) == 3 && segments[0] == "albums" && segments[2] == "tracks":
+		response, errResponse = server.getAlbumTracks(request, segments[1])
+	case len(segments) == 1 && segments[0] == "artists":
+		response, errResponse = server.getArtists(request)
+	case len(segments) == 2 && segments[0] == "artists":
+		response, errResponse = server.getArtist(segments[1])
+	case len(segments) == 3 && segments[0] == "artists" && segments[2] == "albums":
+		response, errResponse = server.getArtistAlbums(request, segments[1])
+	case len(segments) == 3 && segments[0] == "artists" && segments[2] == "top-tracks":
+		response, errResponse = server.getArtistTopTracks(request, segments[1])
+	case len(segments) == 3 && segments[0] == "artists" && segments[2] == "related-artists":
+		response, errResponse = server.getArtistRelatedArtists(segments[1])
+	case len(segments) == 1 && segments[0] == "episodes":
+		response, errResponse = server.getEpisodes(request)
+	case len(segments) == 2 && segments[0] == "episodes":
+		response, errResponse = server.getEpisode(request, segments[1])
+	case len(segments) == 1 && segments[0] == "me":
+		response, errResponse = server.getCurrentUserProfile(request)
+	case len(segments) == 3 && segments[0] == "me" && segments[1] == "top":
+		response, errResponse = server.getUserTop(request, segments[2])
+	case len(segments) == 2 && segments[0] == "users":
+		response, errResponse = server.getUserProfile(segments[1])
+	default:
+		errResponse = errNoService
+	}
+
+	if errResponse != nil {
+		writeRestAPIError(writer, errResponse.statusCode, errResponse.message)
+		return
+	}
+
+	writeJSON(writer, http.StatusOK, response)
+}
+
+// relinkTrack applies market filtering to a track: if a market is given,
+// is_playable tells whether the track is available in it, and
+// available_markets is omitted, like in the Spotify API.
+func relinkTrack(track apiobjects.SimplifiedTrack, market string) apiobjects.SimplifiedTrack {
+	if market == "" {
+		return track
+	}
+
+	track.IsPlayable = availableInMarket(track.AvailableMarkets, market)
+	if !track.IsPlayable {
+		track.Restrictions = apiobjects.Restrictions{Reason: "market"}
+	}
+	track.AvailableMarkets = nil
+	return track
+}
+
+func (server *Server) findAlbum(
+	request apiRequest,
+	albumID string,
+) (apiobjects.FullAlbum, bool) {
+	album, ok := server.catalog.Albums[albumID]
+	market := server.market(request, "market")
+	if !ok || !availableInMarket(album.AvailableMarkets, market) {
+		return apiobjects.FullAlbum{}, false
+	}
+
+	if market != "" {
+		album.AvailableMarkets = nil
+		items := make([]apiobjects.SimplifiedTrack, len(album.Tracks.Items))
+		for i, track := range album.Tracks.Items {
+			items[i] = relinkTrack(track, market)
+		}
+		album.Tracks.Items = items
+	}
+
+	return album, true
+}
+
+func (server *Server) getAlbum(request apiRequest, albumID string) (interface{}, *errorResponse) {
+	album, ok := server.findAlbum(request, albumID)
+	if !ok {
+		return nil, errNotFound
+	}
+
+	tracksRequest := request
+	tracksRequest.path = "albums/" + albumID + "/tracks"
+	tracksRequest.query = url.Values{"limit": []string{"50"}}
+	start, end, paging, errResponse := tracksRequest.page(len(album.Tracks.Items))
+	if errResponse != nil {
+		return nil, errResponse
+	}
+	album.Tracks = apiobjects.SimplifiedTrackPaging{
+		Items:       album.Tracks.Items[start:end],
+		BasicPaging: paging,
+	}
+
+	return album, nil
+}
+
+func (server *Server) getAlbums(request apiRequest) (interface{}, *errorResponse) {
+	ids, errResponse := request.ids(20)
+	if errResponse != nil {
+		return nil, errResponse
+	}
+
+	albums := make([]interface{}, len(ids))
+	for i, id := range ids {
+		if album, errResponse := server.getAlbum(request, id); errResponse == nil {
+			albums[i] = album
+		}
+	}
+
+	return map[string]interface{}{"albums": albums}, nil
+}
+
+func (server *Server) getAlbumTracks(
+	request apiRequest,
+	albumID string,
+) (interface{}, *errorResponse) {
+	album, ok := server.findAlbum(request, albumID)
+	if !ok {
+		return nil, errNotFound
+	}
+
+	start, end, paging, errResponse := request.page(len(album.Tracks.Items))
+	if errResponse != nil {
+		return nil, errResponse
+	}
+
+	return apiobjects.SimplifiedTrackPaging{
+		Items:       album.Tracks.Items[start:end],
+		BasicPaging: paging,
+	}, nil
+}
+
+func (server *Server) getArtist(artistID string) (interface{}, *errorResponse) {
+	artist, ok := server.catalog.Artists[artistID]
+	if !ok {
+		return nil, errNotFound
+	}
+
+	return artist, nil
+}
+
+func (server *Server) getArtists(request apiRequest) (interface{}, *errorResponse) {
+	ids, errResponse := request.ids(50)
+	if errResponse != nil {
+		return nil, errResponse
+	}
+
+	artists := make([]interface{}, len(ids))
+	for i, id := range ids {
+		if artist, ok := server.catalog.Artists[id]; ok {
+			artists[i] = artist
+		}
+	}
+
+	return map[string]interface{}{"artists": artists}, nil
+}
+
+func (server *Server) getArtistAlbums(
+	request apiRequest,
+	artistID string,
+) (interface{}, *errorResponse) {
+	if _, ok := server.catalog.Artists[artistID]; !ok {
+		return nil, errNotFound
+	}
+
+	var includeGroups []string
+	if value := request.query.Get("include_groups"); value != "" {
+		includeGroups = strings.Split(value, ",")
+	}
+
+	country := server.market(request, "country")
+	albums := []apiobjects.SimplifiedAlbum{}
+	for _, album := range server.catalog.artistAlbums(artistID) {
+		if !availableInMarket(album.AvailableMarkets, country) {
+			continue
+		}
+
+		included := len(includeGroups) == 0
+		for _, group := range includeGroups {
+			included = included || group == album.AlbumType
+		}
+		if !included {
+			continue
+		}
+
+		album.AlbumGroup = album.AlbumType
+		if country != "" {
+			album.AvailableMarkets = nil
+		}
+		albums = append(albums, album)
+	}
+
+	start, end, paging, errResponse := request.page(len(albums))
+	if errResponse != nil {
+		return nil, errResponse
+	}
+
+	return apiobjects.SimplifiedAlbumPaging{Items: albums[start:end], BasicPaging: paging}, nil
+}
+
+func (server *Server) getArtistTopTracks(
+	request apiRequest,
+	artistID string,
+) (interface{}, *errorResponse) {
+	if _, ok := server.catalog.Artists[artistID]; !ok {
+		return nil, errNotFound
+	}
+
+	country := server.market(request, "country")
+	if country == "" {
+		return nil, newErrorResponse(http.StatusBadRequest, "Missing country parameter")
+	}
+
+	tracks := server.catalog.artistTopTracks(artistID, country)
+	for i := range tracks {
+		tracks[i].SimplifiedTrack = relinkTrack(tracks[i].SimplifiedTrack, country)
+	}
+
+	return map[string]interface{}{"tracks": tracks}, nil
+}
+
+func (server *Server) getArtistRelatedArtists(artistID string) (interface{}, *errorResponse) {
+	if _, ok := server.catalog.Artists[artistID]; !ok {
+		return nil, errNotFound
+	}
+
+	artists := []apiobjects.FullArtist{}
+	for _, relatedID := range server.catalog.RelatedArtists[artistID] {
+		if artist, ok := server.catalog.Artists[relatedID]; ok && len(artists) < 20 {
+			artists = append(artists, artist)
+		}
+	}
+
+	return map[string]interface{}{"artists": artists}, nil
+}
+
+func (server *Server) getEpisode(
+	request apiRequest,
+	episodeID string,
+) (interface{}, *errorResponse) {
+	episode, ok := server.catalog.Episodes[episodeID]
+	if !ok || !availableInMarket(episode.Show.AvailableMarkets, server.market(request, "market")) {
+		return nil, errNotFound
+	}
+
+	return episode, nil
+}
+
+func (server *Server) getEpisodes(request apiRequest) (interface{}, *errorResponse) {
+	ids, errResponse := request.ids(50)
+	if errResponse != nil {
+		return nil, errResponse
+	}
+
+	episodes := make([]interface{}, len(ids))
+	for i, id := range ids {
+		if episode, errResponse := server.getEpisode(request, id); errResponse == nil {
+			episodes[i] = episode
+		}
+	}
+
+	return map[string]interface{}{"episodes": episodes}, nil
+}
+
+func (server *Server) getCurrentUserProfile(request apiRequest) (interface{}, *errorResponse) {
+	if request.grant.userID == "" {
+		return nil, errUserRequired
+	}
+
+	user, ok := server.catalog.Users[request.grant.userID]
+	if !ok {
+		return nil, errNotFound
+	}
+
+	if !request.hasScope("user-read-email") {
+		user.Email = ""
+	}
+	if !request.hasScope("user-read-private") {
+		user.Country = ""
+		user.Product = ""
+	}
+
+	return user, nil
+}
+
+func (server *Server) getUserTop(
+	request apiRequest,
+	personalizationType string,
+) (interface{}, *errorResponse) {
+	if request.grant.userID == "" {
+		return nil, errUserRequired
+	}
+
+	if !request.hasScope("user-top-read") {
+		return nil, errInsufficientScope
+	}
+
+	switch timeRange := request.query.Get("time_range"); timeRange {
+	case "", "short_term", "medium_term", "long_term":
+	default:
+		return nil, newErrorResponse(http.StatusBadRequest, "Invalid time range")
+	}
+
+	switch personalizationType {
+	case "artists":
+		artists := []apiobjects.FullArtist{}
+		for _, artistID := range server.catalog.TopArtists[request.grant.userID] {
+			if artist, ok := server.catalog.Artists[artistID]; ok {
+				artists = append(artists, artist)
+			}
+		}
+
+		start, end, paging, errResponse := request.page(len(artists))
+		if errResponse != nil {
+			return nil, errResponse
+		}
+
+		return apiobjects.FullArtistPaging{Items: artists[start:end], BasicPaging: paging}, nil
+	case "tracks":
+		tracks := []apiobjects.FullTrack{}
+		for _, trackID := range server.catalog.TopTracks[request.grant.userID] {
+			if track, ok := server.catalog.Tracks[trackID]; ok {
+				tracks = append(tracks, track)
+			}
+		}
+
+		start, end, paging, errResponse := request.page(len(tracks))
+		if errResponse != nil {
+			return nil, errResponse
+		}
+
+		return apiobjects.FullTrackPaging{Items: tracks[start:end], BasicPaging: paging}, nil
+	default:
+		return nil, errNoService
+	}
+}
+
+func (server *Server) getUserProfile(userID string) (interface{}, *errorResponse) {
+	user, ok := server.catalog.Users[userID]
+	if !ok {
+		return nil, errNotFound
+	}
+
+	return user.PublicUser, nil
+}
@@ -0,0 +1,100 @@
+package spotifytest
+
+import (
+	"sort"
+
+	"github.com/taiypeo/spotifygo/apiobjects"
+)
+
+// Catalog is the in-memory data served by a Server.
+// Objects are keyed by their Spotify IDs; RelatedArtists maps an artist ID
+// to the IDs of its related artists, while TopArtists and TopTracks map a user ID
+// to the IDs of the user's top artists and tracks.
+// Albums and tracks with non-empty AvailableMarkets are only available
+// in the listed markets.
+// A Catalog must not be modified while the Server that uses it is handling requests.
+type Catalog struct {
+	Albums         map[string]apiobjects.FullAlbum
+	Artists        map[string]apiobjects.FullArtist
+	Tracks         map[string]apiobjects.FullTrack
+	Episodes       map[string]apiobjects.FullEpisode
+	Users          map[string]apiobjects.PrivateUser
+	RelatedArtists map[string][]string
+	TopArtists     map[string][]string
+	TopTracks      map[string][]string
+}
+
+// NewCatalog creates a new empty Catalog.
+func NewCatalog() *Catalog {
+	return &Catalog{
+		Albums:         make(map[string]apiobjects.FullAlbum),
+		Artists:        make(map[string]apiobjects.FullArtist),
+		Tracks:         make(map[string]apiobjects.FullTrack),
+		Episodes:       make(map[string]apiobjects.FullEpisode),
+		Users:          make(map[string]apiobjects.PrivateUser),
+		RelatedArtists: make(map[string][]string),
+		TopArtists:     make(map[string][]string),
+		TopTracks:      make(map[string][]string),
+	}
+}
+
+func availableInMarket(availableMarkets []string, market string) bool {
+	if market == "" || len(availableMarkets) == 0 {
+		return true
+	}
+
+	for _, availableMarket := range availableMarkets {
+		if availableMarket == market {
+			return true
+		}
+	}
+
+	return false
+}
+
+func hasArtist(artists []apiobjects.SimplifiedArtist, artistID string) bool {
+	for _, artist := range artists {
+		if artist.ID == artistID {
+			return true
+		}
+	}
+
+	return false
+}
+
+// artistAlbums returns the albums of an artist, sorted by ID
+// so that paging over them is deterministic.
+func (catalog *Catalog) artistAlbums(artistID string) []apiobjects.SimplifiedAlbum {
+	var albums []apiobjects.SimplifiedAlbum
+	for _, album := range catalog.Albums {
+		if hasArtist(album.Artists, artistID) {
+			albums = append(albums, album.SimplifiedAlbum)
+		}
+	}
+
+	sort.Slice(albums, func(i, j int) bool { return albums[i].ID < albums[j].ID })
+	return albums
+}
+
+// artistTopTracks returns up to 10 most popular tracks of an artist in a market.
+func (catalog *Catalog) artistTopTracks(artistID, market string) []apiobjects.FullTrack {
+	var tracks []apiobjects.FullTrack
+	for _, track := range catalog.Tracks {
+		if hasArtist(track.Artists, artistID) && availableInMarket(track.AvailableMarkets, market) {
+			tracks = append(tracks, track)
+		}
+	}
+
+	sort.Slice(tracks, func(i, j int) bool {
+		if tracks[i].Popularity != tracks[j].Popularity {
+			return tracks[i].Popularity > tracks[j].Popularity
+		}
+
+		return tracks[i].ID < tracks[j].ID
+	})
+	if len(tracks) > 10 {
+		tracks = tracks[:10]
+	}
+
+	return tracks
+}
@@ -0,0 +1,258 @@
+package spotifytest
+
+import (
+	"crypto/rand"
+	"encoding/hex"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/taiypeo/spotifygo/requests"
+)
+
+// Failure describes error responses that a Server returns instead of
+// handling matching requests.
+// Path is the prefix of the matching REST API sub-URLs, such as "albums/"
+// (an empty Path matches every REST API request);
+// StatusCode and Message form the returned error object;
+// RetryAfter is sent as the Retry-After header if positive;
+// Count is the number of requests to fail (1 if not positive).
+type Failure struct {
+	Path       string
+	StatusCode int
+	Message    string
+	RetryAfter time.Duration
+	Count      int
+}
+
+// Server is an in-process fake of the Spotify Web API and accounts service,
+// which serves the objects of a Catalog and issues tokens for the client credentials,
+// authorization code, PKCE and implicit grant flows.
+// Use Client to get a requests.Client that sends its requests to the server.
+// AuthorizingUserID is the ID of the user that approves every request
+// to the /authorize endpoint; TokenLifetime is the lifetime of issued access tokens.
+// Both can be changed before the requests that use them are made.
+type Server struct {
+	AuthorizingUserID string
+	TokenLifetime     time.Duration
+
+	server  *httptest.Server
+	catalog *Catalog
+
+	mutex         sync.Mutex
+	apps          map[string]string
+	accessTokens  map[string]*grant
+	refreshTokens map[string]*grant
+	codes         map[string]*authorizationCode
+	failures      []*Failure
+	requestLog    []string
+}
+
+// grant is what an access or refresh token gives access to.
+// userID is empty for client credentials tokens.
+type grant struct {
+	clientID  string
+	userID    string
+	scopes    []string
+	pkce      bool
+	expiresAt time.Time
+}
+
+// NewServer starts a new Server that serves the given catalog.
+// The server has to be closed with Close.
+func NewServer(catalog *Catalog) *Server {
+	if catalog == nil {
+		catalog = NewCatalog()
+	}
+
+	server := &Server{
+		TokenLifetime: time.Hour,
+		catalog:       catalog,
+		apps:          make(map[string]string),
+		accessTokens:  make(map[string]*grant),
+		refreshTokens: make(map[string]*grant),
+		codes:         make(map[string]*authorizationCode),
+	}
+	server.server = httptest.NewServer(http.HandlerFunc(server.serveHTTP))
+	return server
+}
+
+// URL returns the base URL of the server.
+func (server *Server) URL() string {
+	return server.server.URL
+}
+
+// Close shuts the server down.
+func (server *Server) Close() {
+	server.server.Close()
+}
+
+// Client returns a new requests.Client that sends its requests to the server.
+// Retries are disabled, so that injected failures reach the caller.
+func (server *Server) Client() *requests.Client {
+	client := requests.NewClient()
+	client.HTTPClient = server.server.Client()
+	client.RestAPIBaseURL = server.URL() + "/v1/"
+	client.AccountsBaseURL = server.URL() + "/"
+	client.RetryPolicy = nil
+	return client
+}
+
+// RegisterApp registers an application, so that the server accepts its credentials.
+func (server *Server) RegisterApp(clientID, clientSecret string) {
+	server.mutex.Lock()
+	defer server.mutex.Unlock()
+
+	server.apps[clientID] = clientSecret
+}
+
+// IssueToken creates an access token for the given user (or for the application
+// itself if userID is empty) with the given scopes, without going through
+// any authorization flow.
+func (server *Server) IssueToken(clientID, userID string, scopes []string) string {
+	server.mutex.Lock()
+	defer server.mutex.Unlock()
+
+	return server.issueAccessToken(&grant{clientID: clientID, userID: userID, scopes: scopes})
+}
+
+// ExpireTokens makes every issued access token expired, while refresh tokens
+// keep working.
+func (server *Server) ExpireTokens() {
+	server.mutex.Lock()
+	defer server.mutex.Unlock()
+
+	expiresAt := time.Now().Add(-time.Second)
+	for _, accessGrant := range server.accessTokens {
+		accessGrant.expiresAt = expiresAt
+	}
+}
+
+// RevokeRefreshToken makes the given refresh token invalid,
+// so that using it results in an invalid_grant error.
+func (server *Server) RevokeRefreshToken(refreshToken string) {
+	server.mutex.Lock()
+	defer server.mutex.Unlock()
+
+	delete(server.refreshTokens, refreshToken)
+}
+
+// InjectFailure makes the server fail the next matching REST API requests.
+func (server *Server) InjectFailure(failure Failure) {
+	if failure.Count < 1 {
+		failure.Count = 1
+	}
+
+	server.mutex.Lock()
+	defer server.mutex.Unlock()
+
+	server.failures = append(server.failures, &failure)
+}
+
+// Requests returns the requests received by the server so far,
+// formatted as "METHOD /path?query".
+func (server *Server) Requests() []string {
+	server.mutex.Lock()
+	defer server.mutex.Unlock()
+
+	return append([]string(nil), server.requestLog...)
+}
+
+func randomString() string {
+	bytes := make([]byte, 16)
+	if _, err := rand.Read(bytes); err != nil {
+		panic(err)
+	}
+
+	return hex.EncodeToString(bytes)
+}
+
+// issueAccessToken must be called with the mutex held.
+func (server *Server) issueAccessToken(tokenGrant *grant) string {
+	accessGrant := *tokenGrant
+	accessGrant.expiresAt = time.Now().Add(server.TokenLifetime)
+
+	accessToken := randomString()
+	server.accessTokens[accessToken] = &accessGrant
+	return accessToken
+}
+
+func writeJSON(writer http.ResponseWriter, statusCode int, value interface{}) {
+	writer.Header().Set("Content-Type", "application/json; charset=utf-8")
+	writer.WriteHeader(statusCode)
+	json.NewEncoder(writer).Encode(value)
+}
+
+// writeRestAPIError writes a regular error object.
+func writeRestAPIError(writer http.ResponseWriter, statusCode int, message string) {
+	writeJSON(writer, statusCode, map[string]interface{}{
+		"error": map[string]interface{}{"status": statusCode, "message": message},
+	})
+}
+
+// writeAuthenticationError writes an authentication error object.
+func writeAuthenticationError(
+	writer http.ResponseWriter,
+	statusCode int,
+	errorHighLevel,
+	errorDescription string,
+) {
+	writeJSON(writer, statusCode, map[string]string{
+		"error":             errorHighLevel,
+		"error_description": errorDescription,
+	})
+}
+
+// takeFailure returns the first injected failure matching subURL, if any.
+func (server *Server) takeFailure(subURL string) (Failure, bool) {
+	server.mutex.Lock()
+	defer server.mutex.Unlock()
+
+	for i, failure := range server.failures {
+		if !strings.HasPrefix(subURL, failure.Path) {
+			continue
+		}
+
+		failure.Count--
+		if failure.Count <= 0 {
+			server.failures = append(server.failures[:i], server.failures[i+1:]...)
+		}
+
+		return *failure, true
+	}
+
+	return Failure{}, false
+}
+
+func (server *Server) serveHTTP(writer http.ResponseWriter, request *http.Request) {
+	server.mutex.Lock()
+	server.requestLog = append(server.requestLog, request.Method+" "+request.URL.RequestURI())
+	server.mutex.Unlock()
+
+	switch {
+	case request.URL.Path == "/api/token":
+		server.serveToken(writer, request)
+	case request.URL.Path == "/authorize":
+		server.serveAuthorize(writer, request)
+	case strings.HasPrefix(request.URL.Path, "/v1/"):
+		subURL := strings.TrimPrefix(request.URL.Path, "/v1/")
+		if failure, ok := server.takeFailure(subURL); ok {
+			if failure.RetryAfter > 0 {
+				writer.Header().Set(
+					"Retry-After",
+					strconv.FormatInt(int64((failure.RetryAfter+time.Second-1)/time.Second), 10),
+				)
+			}
+			writeRestAPIError(writer, failure.StatusCode, failure.Message)
+			return
+		}
+
+		server.serveRestAPI(writer, request, strings.TrimSuffix(subURL, "/"))
+	default:
+		http.NotFound(writer, request)
+	}
+}
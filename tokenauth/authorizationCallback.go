@@ -0,0 +1,140 @@
+package tokenauth
+
+import (
+	"crypto/rand"
+	"crypto/subtle"
+	"encoding/base64"
+	"net/url"
+	"strconv"
+	"strings"
+
+	"github.com/taiypeo/spotifygo/apierrors"
+)
+
+// stateLength is the number of random bytes in a state value.
+const stateLength = 32
+
+// NewState generates a random value for the state parameter
+// of the /authorize endpoint, which protects the callback against
+// cross-site request forgery.
+func NewState() (string, apierrors.TypedError) {
+	bytes := make([]byte, stateLength)
+	if _, err := rand.Read(bytes); err != nil {
+		return "", apierrors.NewBasicErrorFromError(err)
+	}
+
+	return base64.RawURLEncoding.EncodeToString(bytes), nil
+}
+
+// VerifyState returns true if the state received in the callback matches
+// the expected one. The comparison takes constant time.
+func VerifyState(expectedState, receivedState string) bool {
+	return expectedState != "" &&
+		subtle.ConstantTimeCompare([]byte(expectedState), []byte(receivedState)) == 1
+}
+
+// parseCallbackParameters verifies the state of the callback parameters and
+// returns an AuthenticationError if they contain an error.
+func parseCallbackParameters(
+	parameters url.Values,
+	expectedState string,
+) apierrors.TypedError {
+	if !VerifyState(expectedState, parameters.Get("state")) {
+		return apierrors.NewBasicErrorFromString("state of the callback does not match")
+	}
+
+	if errorHighLevel := parameters.Get("error"); errorHighLevel != "" {
+		return &apierrors.AuthenticationError{
+			ErrorHighLevel:   errorHighLevel,
+			ErrorDescription: parameters.Get("error_description"),
+		}
+	}
+
+	return nil
+}
+
+// ParseAuthorizationCallback returns the authorization code from the URL
+// the user was redirected to in the authorization code (or PKCE) flow.
+// callbackURL is the full redirect URL with its query;
+// expectedState is the state passed to GetAuthorizationCodeURL or GetPKCEAuthorizationURL.
+// If the user denied the authorization, an AuthenticationError is returned.
+func ParseAuthorizationCallback(
+	callbackURL,
+	expectedState string,
+) (string, apierrors.TypedError) {
+	parsedURL, basicErr := url.Parse(callbackURL)
+	if basicErr != nil {
+		return "", apierrors.NewBasicErrorFromError(basicErr)
+	}
+
+	query := parsedURL.Query()
+	if typedErr := parseCallbackParameters(query, expectedState); typedErr != nil {
+		return "", typedErr
+	}
+
+	code := query.Get("code")
+	if code == "" {
+		return "", apierrors.NewBasicErrorFromString("code is missing in the callback")
+	}
+
+	return code, nil
+}
+
+// ParseImplicitGrantCallback returns the token from the URL the user was
+// redirected to in the implicit grant flow.
+// callbackURL is the full redirect URL with its fragment (or, for errors, its query);
+// expectedState is the state passed to GetImplicitGrantURL.
+// If the user denied the authorization, an AuthenticationError is returned.
+// The redirect does not include the granted scopes, so the scopes of the token are unknown.
+func ParseImplicitGrantCallback(
+	callbackURL,
+	expectedState string,
+) (ScopedAuthToken, apierrors.TypedError) {
+	parsedURL, basicErr := url.Parse(callbackURL)
+	if basicErr != nil {
+		return ScopedAuthToken{}, apierrors.NewBasicErrorFromError(basicErr)
+	}
+
+	fragment, basicErr := url.ParseQuery(parsedURL.Fragment)
+	if basicErr != nil {
+		return ScopedAuthToken{}, apierrors.NewBasicErrorFromError(basicErr)
+	}
+
+	// Spotify sends the errors of the implicit grant flow, such as access_denied,
+	// in the query instead of the fragment.
+	parameters := fragment
+	if query := parsedURL.Query(); query.Get("error") != "" && fragment.Get("access_token") == "" {
+		parameters = query
+	}
+
+	if typedErr := parseCallbackParameters(parameters, expectedState); typedErr != nil {
+		return ScopedAuthToken{}, typedErr
+	}
+
+	accessToken := fragment.Get("access_token")
+	if accessToken == "" {
+		return ScopedAuthToken{}, apierrors.NewBasicErrorFromString(
+			"access_token is missing in the callback",
+		)
+	}
+
+	if !strings.EqualFold(fragment.Get("token_type"), "Bearer") {
+		return ScopedAuthToken{}, apierrors.NewBasicErrorFromString(
+			"token_type is not Bearer in the callback",
+		)
+	}
+
+	expiresIn, basicErr := strconv.ParseInt(fragment.Get("expires_in"), 10, 64)
+	if basicErr != nil || expiresIn <= 0 {
+		return ScopedAuthToken{}, apierrors.NewBasicErrorFromString(
+			"expires_in is missing or invalid in the callback",
+		)
+	}
+
+	var scope []string
+	if scopeString := fragment.Get("scope"); scopeString != "" {
+		scope = strings.Split(scopeString, " ")
+	}
+
+	return NewScopedAuthToken(accessToken, expiresIn, scope), nil
+}
@@ -1,9 +1,9 @@
 package tokenauth
 
 import (
-	"encoding/base64"
+	"context"
 	"encoding/json"
-	"fmt"
+	"net/url"
 	"strings"
 	"time"
 
@@ -40,18 +40,53 @@ func NewRefreshableAuthToken(
 	clientID,
 	clientSecret string,
 ) (RefreshableAuthToken, apierrors.TypedError) {
-	payload := fmt.Sprintf(
-		"grant_type=authorization_code&code=%s&redirect_uri=%s",
+	return NewRefreshableAuthTokenWithClient(
+		requests.DefaultClient,
 		authCode,
 		redirectURI,
+		clientID,
+		clientSecret,
 	)
-	encodedAuthorizationHeader := "Basic " + base64.StdEncoding.EncodeToString(
-		[]byte(fmt.Sprintf("%s:%s", clientID, clientSecret)),
+}
+
+// NewRefreshableAuthTokenWithClient is the same as NewRefreshableAuthToken, but performs
+// the request using the given client.
+func NewRefreshableAuthTokenWithClient(
+	client *requests.Client,
+	authCode,
+	redirectURI,
+	clientID,
+	clientSecret string,
+) (RefreshableAuthToken, apierrors.TypedError) {
+	return NewRefreshableAuthTokenWithContext(
+		context.Background(),
+		client,
+		authCode,
+		redirectURI,
+		clientID,
+		clientSecret,
 	)
+}
 
-	response, err := requests.PostAuthorization(
-		map[string]string{"Authorization": encodedAuthorizationHeader},
-		payload,
+// NewRefreshableAuthTokenWithContext is the same as NewRefreshableAuthTokenWithClient, but performs
+// the request with the given context. A nil client means requests.DefaultClient.
+func NewRefreshableAuthTokenWithContext(
+	ctx context.Context,
+	client *requests.Client,
+	authCode,
+	redirectURI,
+	clientID,
+	clientSecret string,
+) (RefreshableAuthToken, apierrors.TypedError) {
+	response, err := client.PostTokenWithContext(
+		ctx,
+		url.Values{
+			"grant_type":   {"authorization_code"},
+			"code":         {authCode},
+			"redirect_uri": {redirectURI},
+		},
+		clientID,
+		clientSecret,
 	)
 	if err != nil {
 		return RefreshableAuthToken{}, err
@@ -74,14 +109,32 @@ func NewRefreshableAuthToken(
 // clientId is the Spotify application client id;
 // clientSecret is the Spotify application client secret.
 func (auth *RefreshableAuthToken) Refresh(clientID, clientSecret string) apierrors.TypedError {
-	payload := fmt.Sprintf("grant_type=refresh_token&refresh_token=%s", auth.RefreshToken)
-	encodedAuthorizationHeader := "Basic " + base64.StdEncoding.EncodeToString(
-		[]byte(fmt.Sprintf("%s:%s", clientID, clientSecret)),
-	)
+	return auth.RefreshWithClient(requests.DefaultClient, clientID, clientSecret)
+}
+
+// RefreshWithClient is the same as Refresh, but performs
+// the request using the given client.
+func (auth *RefreshableAuthToken) RefreshWithClient(
+	client *requests.Client,
+	clientID,
+	clientSecret string,
+) apierrors.TypedError {
+	return auth.RefreshWithContext(context.Background(), client, clientID, clientSecret)
+}
 
-	response, err := requests.PostAuthorization(
-		map[string]string{"Authorization": encodedAuthorizationHeader},
-		payload,
+// RefreshWithContext is the same as RefreshWithClient, but performs
+// the request with the given context. A nil client means requests.DefaultClient.
+func (auth *RefreshableAuthToken) RefreshWithContext(
+	ctx context.Context,
+	client *requests.Client,
+	clientID,
+	clientSecret string,
+) apierrors.TypedError {
+	response, err := client.PostTokenWithContext(
+		ctx,
+		url.Values{"grant_type": {"refresh_token"}, "refresh_token": {auth.RefreshToken}},
+		clientID,
+		clientSecret,
 	)
 	if err != nil {
 		return err
@@ -101,6 +154,9 @@ func (auth *RefreshableAuthToken) Refresh(clientID, clientSecret string) apierro
 	auth.ExpiresIn = refreshedToken.ExpiresIn
 	auth.ScopeString = refreshedToken.ScopeString
 	auth.Scope = strings.Split(refreshedToken.ScopeString, " ")
+	if refreshedToken.RefreshToken != "" {
+		auth.RefreshToken = refreshedToken.RefreshToken
+	}
 
 	return nil
 }
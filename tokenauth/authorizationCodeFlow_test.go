@@ -0,0 +1,254 @@
+package tokenauth_test
+
+import (
+	"context"
+	"encoding/base64"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"testing"
+
+	"github.com/taiypeo/spotifygo/requests"
+	"github.com/taiypeo/spotifygo/tokenauth"
+)
+
+const (
+	testClientID     = "client+id/1="
+	testClientSecret = "secret+/=:&%"
+	testRedirectURI  = "http://127.0.0.1:8080/callback?next=/home&lang=en+US"
+	testAuthCode     = "code+/="
+	testRefreshToken = "AQB+refresh/token=="
+	testCodeVerifier = "verifier-._~+/="
+)
+
+// tokenRequest is a request received by the fake token endpoint.
+type tokenRequest struct {
+	form          url.Values
+	authorization string
+	contentType   string
+}
+
+// newTokenServer starts a fake token endpoint that records the received requests.
+func newTokenServer(t *testing.T, received *[]tokenRequest) *httptest.Server {
+	server := httptest.NewServer(http.HandlerFunc(func(
+		writer http.ResponseWriter,
+		request *http.Request,
+	) {
+		if err := request.ParseForm(); err != nil {
+			t.Errorf("cannot parse the form: %v", err)
+		}
+
+		*received = append(*received, tokenRequest{
+			form:          request.PostForm,
+			authorization: request.Header.Get("Authorization"),
+			contentType:   request.Header.Get("Content-Type"),
+		})
+
+		writer.Header().Set("Content-Type", "application/json")
+		fmt.Fprintf(
+			writer,
+			`{"access_token":"access","token_type":"Bearer","expires_in":3600,`+
+				`"scope":"user-top-read","refresh_token":%q}`,
+			testRefreshToken,
+		)
+	}))
+	t.Cleanup(server.Close)
+
+	return server
+}
+
+// checkClientAuth checks that the application credentials were sent
+// according to style and survived the encoding.
+func checkClientAuth(t *testing.T, request tokenRequest, style requests.ClientAuthStyle) {
+	t.Helper()
+
+	switch style {
+	case requests.ClientAuthInHeader:
+		if request.form.Get("client_id") != "" || request.form.Get("client_secret") != "" {
+			t.Errorf("credentials are sent in the form: %v", request.form)
+		}
+
+		if !strings.HasPrefix(request.authorization, "Basic ") {
+			t.Fatalf("Authorization is %q, expected Basic credentials", request.authorization)
+		}
+		decoded, err := base64.StdEncoding.DecodeString(
+			strings.TrimPrefix(request.authorization, "Basic "),
+		)
+		if err != nil {
+			t.Fatalf("cannot decode the Basic credentials: %v", err)
+		}
+
+		// The credentials are form-encoded before being joined, as RFC 6749 requires.
+		parts := strings.SplitN(string(decoded), ":", 2)
+		if len(parts) != 2 {
+			t.Fatalf("Basic credentials %q have no separator", decoded)
+		}
+		clientID, err := url.QueryUnescape(parts[0])
+		if err != nil || clientID != testClientID {
+			t.Errorf("client ID is %q (%v), expected %q", clientID, err, testClientID)
+		}
+		clientSecret, err := url.QueryUnescape(parts[1])
+		if err != nil || clientSecret != testClientSecret {
+			t.Errorf("client secret is %q (%v), expected %q", clientSecret, err, testClientSecret)
+		}
+	case requests.ClientAuthInBody:
+		if request.authorization != "" {
+			t.Errorf("Authorization is %q, expected none", request.authorization)
+		}
+		if clientID := request.form.Get("client_id"); clientID != testClientID {
+			t.Errorf("client_id is %q, expected %q", clientID, testClientID)
+		}
+		if clientSecret := request.form.Get("client_secret"); clientSecret != testClientSecret {
+			t.Errorf("client_secret is %q, expected %q", clientSecret, testClientSecret)
+		}
+	}
+}
+
+func TestTokenRequestsEncodeSpecialCharacters(t *testing.T) {
+	styles := map[string]requests.ClientAuthStyle{
+		"ClientAuthInHeader": requests.ClientAuthInHeader,
+		"ClientAuthInBody":   requests.ClientAuthInBody,
+	}
+
+	for name, style := range styles {
+		style := style
+		t.Run(name, func(t *testing.T) {
+			var received []tokenRequest
+			server := newTokenServer(t, &received)
+
+			client := requests.NewClient()
+			client.AccountsBaseURL = server.URL + "/"
+			client.ClientAuthStyle = style
+
+			token, typedErr := tokenauth.NewRefreshableAuthTokenWithContext(
+				context.Background(),
+				client,
+				testAuthCode,
+				testRedirectURI,
+				testClientID,
+				testClientSecret,
+			)
+			if typedErr != nil {
+				t.Fatalf("cannot exchange the code: %v", typedErr)
+			}
+
+			if typedErr := token.RefreshWithContext(
+				context.Background(),
+				client,
+				testClientID,
+				testClientSecret,
+			); typedErr != nil {
+				t.Fatalf("cannot refresh the token: %v", typedErr)
+			}
+
+			if len(received) != 2 {
+				t.Fatalf("got %d token requests, expected 2", len(received))
+			}
+
+			expectedForms := []map[string]string{
+				{
+					"grant_type":   "authorization_code",
+					"code":         testAuthCode,
+					"redirect_uri": testRedirectURI,
+				},
+				{
+					"grant_type":    "refresh_token",
+					"refresh_token": testRefreshToken,
+				},
+			}
+			for i, expectedForm := range expectedForms {
+				request := received[i]
+				if request.contentType != "application/x-www-form-urlencoded" {
+					t.Errorf("Content-Type is %q", request.contentType)
+				}
+
+				for key, expectedValue := range expectedForm {
+					if value := request.form.Get(key); value != expectedValue {
+						t.Errorf("%s is %q, expected %q", key, value, expectedValue)
+					}
+				}
+
+				checkClientAuth(t, request, style)
+			}
+		})
+	}
+}
+
+func TestPKCETokenRequestsEncodeSpecialCharacters(t *testing.T) {
+	styles := map[string]requests.ClientAuthStyle{
+		"ClientAuthInHeader": requests.ClientAuthInHeader,
+		"ClientAuthInBody":   requests.ClientAuthInBody,
+	}
+
+	for name, style := range styles {
+		style := style
+		t.Run(name, func(t *testing.T) {
+			var received []tokenRequest
+			server := newTokenServer(t, &received)
+
+			client := requests.NewClient()
+			client.AccountsBaseURL = server.URL + "/"
+			client.ClientAuthStyle = style
+
+			token, typedErr := tokenauth.NewPKCERefreshableAuthTokenWithContext(
+				context.Background(),
+				client,
+				testAuthCode,
+				testRedirectURI,
+				testClientID,
+				testCodeVerifier,
+			)
+			if typedErr != nil {
+				t.Fatalf("cannot exchange the code: %v", typedErr)
+			}
+			if token.RefreshToken != testRefreshToken {
+				t.Errorf("refresh token is %q, expected %q", token.RefreshToken, testRefreshToken)
+			}
+
+			if typedErr := token.RefreshWithContext(
+				context.Background(),
+				client,
+				testClientID,
+			); typedErr != nil {
+				t.Fatalf("cannot refresh the token: %v", typedErr)
+			}
+
+			if len(received) != 2 {
+				t.Fatalf("got %d token requests, expected 2", len(received))
+			}
+
+			expectedForms := []map[string]string{
+				{
+					"grant_type":    "authorization_code",
+					"code":          testAuthCode,
+					"redirect_uri":  testRedirectURI,
+					"code_verifier": testCodeVerifier,
+					"client_id":     testClientID,
+				},
+				{
+					"grant_type":    "refresh_token",
+					"refresh_token": testRefreshToken,
+					"client_id":     testClientID,
+				},
+			}
+			for i, expectedForm := range expectedForms {
+				request := received[i]
+				for key, expectedValue := range expectedForm {
+					if value := request.form.Get(key); value != expectedValue {
+						t.Errorf("%s is %q, expected %q", key, value, expectedValue)
+					}
+				}
+
+				// PKCE clients are public, so no secret is sent in either style.
+				if request.authorization != "" {
+					t.Errorf("Authorization is %q, expected none", request.authorization)
+				}
+				if _, ok := request.form["client_secret"]; ok {
+					t.Errorf("client_secret is sent in the form: %v", request.form)
+				}
+			}
+		})
+	}
+}
@@ -0,0 +1,136 @@
+package tokenauth
+
+import (
+	"net/url"
+	"strconv"
+
+	"github.com/taiypeo/spotifygo/apierrors"
+	"github.com/taiypeo/spotifygo/requests"
+)
+
+// getAuthorizationURL builds the URL of the /authorize endpoint with the common
+// and the given flow-specific query parameters.
+func getAuthorizationURL(
+	client *requests.Client,
+	responseType,
+	clientID,
+	redirectURI,
+	state string,
+	scopes []Scope,
+	showDialog bool,
+	parameters url.Values,
+) (string, apierrors.TypedError) {
+	if clientID == "" || redirectURI == "" {
+		return "", apierrors.NewBasicErrorFromString("clientID and redirectURI must not be empty")
+	}
+	if state == "" {
+		return "", apierrors.NewBasicErrorFromString("state must not be empty")
+	}
+
+	authorizeURL, err := client.GetAuthorizeURL()
+	if err != nil {
+		return "", err
+	}
+
+	scope, err := joinScopes(scopes)
+	if err != nil {
+		return "", err
+	}
+
+	query := url.Values{}
+	for key, values := range parameters {
+		query[key] = values
+	}
+	query.Set("client_id", clientID)
+	query.Set("response_type", responseType)
+	query.Set("redirect_uri", redirectURI)
+	query.Set("state", state)
+	if scope != "" {
+		query.Set("scope", scope)
+	}
+	if showDialog {
+		query.Set("show_dialog", strconv.FormatBool(showDialog))
+	}
+
+	return authorizeURL + "?" + query.Encode(), nil
+}
+
+// GetAuthorizationCodeURL returns the URL of the /authorize endpoint that
+// the user has to visit to start the authorization code flow.
+// client determines the accounts service URL (requests.DefaultClient if nil);
+// clientID is the Spotify application client id;
+// redirectURI is the URI the user is redirected to after the authorization;
+// state is a value generated by NewState that has to be verified in the callback;
+// scopes are the requested scopes;
+// showDialog forces the user to approve the application again.
+func GetAuthorizationCodeURL(
+	client *requests.Client,
+	clientID,
+	redirectURI,
+	state string,
+	scopes []Scope,
+	showDialog bool,
+) (string, apierrors.TypedError) {
+	return getAuthorizationURL(
+		client,
+		"code",
+		clientID,
+		redirectURI,
+		state,
+		scopes,
+		showDialog,
+		nil,
+	)
+}
+
+// GetPKCEAuthorizationURL is the same as GetAuthorizationCodeURL, but starts
+// the authorization code flow with proof key for code exchange (PKCE).
+// codeChallenge is the S256 challenge of the code verifier that is later passed
+// to NewPKCERefreshableAuthToken.
+func GetPKCEAuthorizationURL(
+	client *requests.Client,
+	clientID,
+	redirectURI,
+	state,
+	codeChallenge string,
+	scopes []Scope,
+	showDialog bool,
+) (string, apierrors.TypedError) {
+	if codeChallenge == "" {
+		return "", apierrors.NewBasicErrorFromString("codeChallenge must not be empty")
+	}
+
+	return getAuthorizationURL(
+		client,
+		"code",
+		clientID,
+		redirectURI,
+		state,
+		scopes,
+		showDialog,
+		url.Values{"code_challenge_method": {"S256"}, "code_challenge": {codeChallenge}},
+	)
+}
+
+// GetImplicitGrantURL is the same as GetAuthorizationCodeURL, but starts
+// the implicit grant flow, so the access token is returned in the fragment
+// of the redirect URI.
+func GetImplicitGrantURL(
+	client *requests.Client,
+	clientID,
+	redirectURI,
+	state string,
+	scopes []Scope,
+	showDialog bool,
+) (string, apierrors.TypedError) {
+	return getAuthorizationURL(
+		client,
+		"token",
+		clientID,
+		redirectURI,
+		state,
+		scopes,
+		showDialog,
+		nil,
+	)
+}
@@ -1,9 +1,9 @@
 package tokenauth
 
 import (
-	"encoding/base64"
+	"context"
 	"encoding/json"
-	"fmt"
+	"net/url"
 	"time"
 
 	"github.com/taiypeo/spotifygo"
@@ -46,13 +46,32 @@ func NewAuthToken(
 	clientID,
 	clientSecret string,
 ) (AuthToken, apierrors.TypedError) {
-	encodedAuthorizationHeader := "Basic " + base64.StdEncoding.EncodeToString(
-		[]byte(fmt.Sprintf("%s:%s", clientID, clientSecret)),
-	)
+	return NewAuthTokenWithClient(requests.DefaultClient, clientID, clientSecret)
+}
+
+// NewAuthTokenWithClient is the same as NewAuthToken, but performs
+// the request using the given client.
+func NewAuthTokenWithClient(
+	client *requests.Client,
+	clientID,
+	clientSecret string,
+) (AuthToken, apierrors.TypedError) {
+	return NewAuthTokenWithContext(context.Background(), client, clientID, clientSecret)
+}
 
-	response, err := requests.PostAuthorization(
-		map[string]string{"Authorization": encodedAuthorizationHeader},
-		"grant_type=client_credentials",
+// NewAuthTokenWithContext is the same as NewAuthTokenWithClient, but performs
+// the request with the given context. A nil client means requests.DefaultClient.
+func NewAuthTokenWithContext(
+	ctx context.Context,
+	client *requests.Client,
+	clientID,
+	clientSecret string,
+) (AuthToken, apierrors.TypedError) {
+	response, err := client.PostTokenWithContext(
+		ctx,
+		url.Values{"grant_type": {"client_credentials"}},
+		clientID,
+		clientSecret,
 	)
 	if err != nil {
 		return AuthToken{}, err
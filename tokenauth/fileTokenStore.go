@@ -0,0 +1,164 @@
+package tokenauth
+
+import (
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/hex"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+
+	"github.com/taiypeo/spotifygo/apierrors"
+)
+
+// FileTokenStore is a TokenStore that stores every token in its own file
+// in a directory. Files are written atomically and are only readable
+// by their owner (0600).
+// If the store was created with NewEncryptedFileTokenStore, tokens are
+// encrypted with AES-GCM, and every file is bound to its key, so that
+// files cannot be swapped between keys.
+type FileTokenStore struct {
+	directory string
+	aead      cipher.AEAD
+}
+
+// NewFileTokenStore creates a new FileTokenStore in the given directory,
+// creating the directory if it does not exist.
+func NewFileTokenStore(directory string) (*FileTokenStore, apierrors.TypedError) {
+	if err := os.MkdirAll(directory, 0700); err != nil {
+		return nil, apierrors.NewBasicErrorFromError(err)
+	}
+
+	return &FileTokenStore{directory: directory}, nil
+}
+
+// NewEncryptedFileTokenStore is the same as NewFileTokenStore, but encrypts
+// the tokens with AES-GCM. encryptionKey has to be 16, 24 or 32 bytes long
+// (AES-128, AES-192 or AES-256) and must be kept secret.
+func NewEncryptedFileTokenStore(
+	directory string,
+	encryptionKey []byte,
+) (*FileTokenStore, apierrors.TypedError) {
+	block, err := aes.NewCipher(encryptionKey)
+	if err != nil {
+		return nil, apierrors.NewBasicErrorFromError(err)
+	}
+
+	aead, err := cipher.NewGCM(block)
+	if err != nil {
+		return nil, apierrors.NewBasicErrorFromError(err)
+	}
+
+	store, typedErr := NewFileTokenStore(directory)
+	if typedErr != nil {
+		return nil, typedErr
+	}
+
+	store.aead = aead
+	return store, nil
+}
+
+func (store *FileTokenStore) path(key string) string {
+	keyHash := sha256.Sum256([]byte(key))
+	return filepath.Join(store.directory, hex.EncodeToString(keyHash[:])+".json")
+}
+
+func (store *FileTokenStore) encrypt(key string, data []byte) ([]byte, apierrors.TypedError) {
+	if store.aead == nil {
+		return data, nil
+	}
+
+	nonce := make([]byte, store.aead.NonceSize())
+	if _, err := rand.Read(nonce); err != nil {
+		return nil, apierrors.NewBasicErrorFromError(err)
+	}
+
+	return store.aead.Seal(nonce, nonce, data, []byte(key)), nil
+}
+
+func (store *FileTokenStore) decrypt(key string, data []byte) ([]byte, apierrors.TypedError) {
+	if store.aead == nil {
+		return data, nil
+	}
+
+	nonceSize := store.aead.NonceSize()
+	if len(data) < nonceSize {
+		return nil, apierrors.NewBasicErrorFromString("Encrypted token is too short")
+	}
+
+	plaintext, err := store.aead.Open(nil, data[:nonceSize], data[nonceSize:], []byte(key))
+	if err != nil {
+		return nil, apierrors.NewBasicErrorFromError(err)
+	}
+
+	return plaintext, nil
+}
+
+// Load returns the token stored under key, so FileTokenStore implements TokenStore.
+func (store *FileTokenStore) Load(key string) (Token, bool, apierrors.TypedError) {
+	data, err := ioutil.ReadFile(store.path(key))
+	if os.IsNotExist(err) {
+		return nil, false, nil
+	} else if err != nil {
+		return nil, false, apierrors.NewBasicErrorFromError(err)
+	}
+
+	data, typedErr := store.decrypt(key, data)
+	if typedErr != nil {
+		return nil, false, typedErr
+	}
+
+	token, typedErr := UnmarshalToken(data)
+	if typedErr != nil {
+		return nil, false, typedErr
+	}
+
+	return token, true, nil
+}
+
+// Save stores a token under key, so FileTokenStore implements TokenStore.
+func (store *FileTokenStore) Save(key string, token Token) apierrors.TypedError {
+	data, typedErr := MarshalToken(token)
+	if typedErr != nil {
+		return typedErr
+	}
+
+	data, typedErr = store.encrypt(key, data)
+	if typedErr != nil {
+		return typedErr
+	}
+
+	// TempFile creates the file with 0600 permissions.
+	file, err := ioutil.TempFile(store.directory, "tmp-")
+	if err != nil {
+		return apierrors.NewBasicErrorFromError(err)
+	}
+
+	_, writeErr := file.Write(data)
+	closeErr := file.Close()
+	if writeErr == nil {
+		writeErr = closeErr
+	}
+	if writeErr != nil {
+		os.Remove(file.Name())
+		return apierrors.NewBasicErrorFromError(writeErr)
+	}
+
+	if err := os.Rename(file.Name(), store.path(key)); err != nil {
+		os.Remove(file.Name())
+		return apierrors.NewBasicErrorFromError(err)
+	}
+
+	return nil
+}
+
+// Delete removes the token stored under key, so FileTokenStore implements TokenStore.
+func (store *FileTokenStore) Delete(key string) apierrors.TypedError {
+	if err := os.Remove(store.path(key)); err != nil && !os.IsNotExist(err) {
+		return apierrors.NewBasicErrorFromError(err)
+	}
+
+	return nil
+}
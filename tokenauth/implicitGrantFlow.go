@@ -30,6 +30,7 @@ func (auth *ScopedAuthToken) Validate() apierrors.TypedError {
 // NewScopedAuthToken creates a new ScopedAuthToken.
 // As the bulk of the work is done in the browser, this function only needs to
 // fetch that processed data and save it to a struct.
+// To create the token from the redirect URL directly, use ParseImplicitGrantCallback.
 // accessToken is the access token returned from the /authorize endpoint;
 // expiresIn is the time (in seconds) until accessToken expires;
 // scope is the slice of scopes provided to /authorize.
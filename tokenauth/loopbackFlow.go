@@ -0,0 +1,220 @@
+package tokenauth
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"net/url"
+	"os"
+	"os/exec"
+	"runtime"
+	"time"
+
+	"github.com/taiypeo/spotifygo/apierrors"
+	"github.com/taiypeo/spotifygo/requests"
+)
+
+// loopbackShutdownTimeout is the time given to the loopback server
+// to finish responding to the callback before it is closed.
+const loopbackShutdownTimeout = 5 * time.Second
+
+// LoopbackFlow obtains a user token in CLI and desktop applications: it starts
+// a temporary HTTP server on RedirectURI, shows the /authorize URL to the user,
+// waits for the callback, verifies its state and exchanges the authorization code.
+// ClientID is the Spotify application client id;
+// ClientSecret is the Spotify application client secret, if it is empty,
+// the authorization code flow with PKCE is used;
+// RedirectURI is a registered redirect URI on a loopback host,
+// such as http://127.0.0.1:8888/callback;
+// Scopes are the requested scopes;
+// ShowDialog forces the user to approve the application again;
+// OpenURL, if not nil, is called with the /authorize URL (for example, OpenBrowser);
+// Output is where the /authorize URL is printed (os.Stderr if nil).
+type LoopbackFlow struct {
+	ClientID     string
+	ClientSecret string
+	RedirectURI  string
+	Scopes       []Scope
+	ShowDialog   bool
+	OpenURL      func(url string) error
+	Output       io.Writer
+}
+
+// OpenBrowser opens the URL in the default browser of the user.
+func OpenBrowser(url string) error {
+	var command *exec.Cmd
+	switch runtime.GOOS {
+	case "darwin":
+		command = exec.Command("open", url)
+	case "windows":
+		command = exec.Command("rundll32", "url.dll,FileProtocolHandler", url)
+	default:
+		command = exec.Command("xdg-open", url)
+	}
+
+	return command.Start()
+}
+
+// isLoopbackHost returns true if host is a loopback address or localhost.
+func isLoopbackHost(host string) bool {
+	if host == "localhost" {
+		return true
+	}
+
+	ip := net.ParseIP(host)
+	return ip != nil && ip.IsLoopback()
+}
+
+// loopbackResult is the outcome of the callback request.
+type loopbackResult struct {
+	code string
+	err  apierrors.TypedError
+}
+
+// Run is the same as RunWithClient, but uses requests.DefaultClient.
+func (flow *LoopbackFlow) Run(ctx context.Context) (*TokenSource, apierrors.TypedError) {
+	return flow.RunWithClient(ctx, requests.DefaultClient)
+}
+
+// RunWithClient runs the flow and returns a TokenSource with the obtained token.
+// The flow waits for a callback with the expected state (other requests are answered
+// with 400 Bad Request and ignored) until ctx is canceled or its deadline expires,
+// in which case a ContextError is returned.
+// client is used for the token requests (requests.DefaultClient if nil).
+func (flow *LoopbackFlow) RunWithClient(
+	ctx context.Context,
+	client *requests.Client,
+) (*TokenSource, apierrors.TypedError) {
+	redirectURL, basicErr := url.Parse(flow.RedirectURI)
+	if basicErr != nil {
+		return nil, apierrors.NewBasicErrorFromError(basicErr)
+	}
+	if redirectURL.Scheme != "http" || !isLoopbackHost(redirectURL.Hostname()) {
+		return nil, apierrors.NewBasicErrorFromString(
+			"RedirectURI must be an http URI on a loopback host",
+		)
+	}
+
+	pkceFlow, typedErr := NewPKCEFlow(flow.ClientID, flow.RedirectURI, flow.Scopes)
+	if typedErr != nil {
+		return nil, typedErr
+	}
+	pkceFlow.ShowDialog = flow.ShowDialog
+
+	var authorizationURL string
+	if flow.ClientSecret == "" {
+		authorizationURL, typedErr = pkceFlow.AuthorizationURLWithClient(client)
+	} else {
+		authorizationURL, typedErr = GetAuthorizationCodeURL(
+			client,
+			flow.ClientID,
+			flow.RedirectURI,
+			pkceFlow.State,
+			flow.Scopes,
+			flow.ShowDialog,
+		)
+	}
+	if typedErr != nil {
+		return nil, typedErr
+	}
+
+	listener, basicErr := net.Listen("tcp", redirectURL.Host)
+	if basicErr != nil {
+		return nil, apierrors.NewBasicErrorFromError(basicErr)
+	}
+
+	results := make(chan loopbackResult, 1)
+	callbackPath := redirectURL.EscapedPath()
+	if callbackPath == "" {
+		callbackPath = "/"
+	}
+
+	server := &http.Server{
+		Handler: http.HandlerFunc(func(writer http.ResponseWriter, request *http.Request) {
+			if request.URL.EscapedPath() != callbackPath {
+				http.NotFound(writer, request)
+				return
+			}
+
+			// Requests that do not carry the state of this flow, such as stale tabs
+			// or prefetches, are rejected without ending the flow.
+			if !VerifyState(pkceFlow.State, request.URL.Query().Get("state")) {
+				http.Error(writer, "state of the callback does not match", http.StatusBadRequest)
+				return
+			}
+
+			callbackURL := *redirectURL
+			callbackURL.RawQuery = request.URL.RawQuery
+			code, err := ParseAuthorizationCallback(callbackURL.String(), pkceFlow.State)
+			if err != nil {
+				http.Error(writer, "Authorization failed: "+err.Error(), http.StatusBadRequest)
+			} else {
+				fmt.Fprintln(writer, "Authorization complete, you can close this window.")
+			}
+
+			select {
+			case results <- loopbackResult{code: code, err: err}:
+			default:
+			}
+		}),
+	}
+	go server.Serve(listener)
+	defer func() {
+		shutdownCtx, cancel := context.WithTimeout(context.Background(), loopbackShutdownTimeout)
+		defer cancel()
+		server.Shutdown(shutdownCtx)
+	}()
+
+	output := flow.Output
+	if output == nil {
+		output = os.Stderr
+	}
+	fmt.Fprintf(output, "Open the following URL to authorize the application:\n%s\n", authorizationURL)
+	if flow.OpenURL != nil {
+		if basicErr := flow.OpenURL(authorizationURL); basicErr != nil {
+			fmt.Fprintf(output, "Could not open the URL: %v\n", basicErr)
+		}
+	}
+
+	var result loopbackResult
+	select {
+	case result = <-results:
+	case <-ctx.Done():
+		return nil, apierrors.NewContextError(ctx.Err())
+	}
+	if result.err != nil {
+		return nil, result.err
+	}
+
+	if flow.ClientSecret == "" {
+		token, typedErr := NewPKCERefreshableAuthTokenWithContext(
+			ctx,
+			client,
+			result.code,
+			flow.RedirectURI,
+			flow.ClientID,
+			pkceFlow.CodeVerifier,
+		)
+		if typedErr != nil {
+			return nil, typedErr
+		}
+
+		return NewPKCETokenSource(client, token, flow.ClientID), nil
+	}
+
+	token, typedErr := NewRefreshableAuthTokenWithContext(
+		ctx,
+		client,
+		result.code,
+		flow.RedirectURI,
+		flow.ClientID,
+		flow.ClientSecret,
+	)
+	if typedErr != nil {
+		return nil, typedErr
+	}
+
+	return NewRefreshableTokenSource(client, token, flow.ClientID, flow.ClientSecret), nil
+}
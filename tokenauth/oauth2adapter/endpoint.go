@@ -0,0 +1,36 @@
+package oauth2adapter
+
+import (
+	"golang.org/x/oauth2"
+
+	"github.com/taiypeo/spotifygo/apierrors"
+	"github.com/taiypeo/spotifygo/requests"
+)
+
+// Endpoint is the OAuth 2.0 endpoint of the Spotify accounts service,
+// to be used in oauth2.Config.
+var Endpoint = oauth2.Endpoint{
+	AuthURL:   requests.DefaultAccountsBaseURL + "authorize",
+	TokenURL:  requests.DefaultAccountsBaseURL + "api/token",
+	AuthStyle: oauth2.AuthStyleInHeader,
+}
+
+// EndpointForClient returns the OAuth 2.0 endpoint of the accounts service
+// that the given client uses (requests.DefaultClient if nil).
+func EndpointForClient(client *requests.Client) (oauth2.Endpoint, apierrors.TypedError) {
+	authURL, err := client.GetAuthorizeURL()
+	if err != nil {
+		return oauth2.Endpoint{}, err
+	}
+
+	tokenURL, err := client.GetTokenURL()
+	if err != nil {
+		return oauth2.Endpoint{}, err
+	}
+
+	return oauth2.Endpoint{
+		AuthURL:   authURL,
+		TokenURL:  tokenURL,
+		AuthStyle: oauth2.AuthStyleInHeader,
+	}, nil
+}
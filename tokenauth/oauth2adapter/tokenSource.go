@@ -0,0 +1,139 @@
+package oauth2adapter
+
+import (
+	"context"
+	"strings"
+	"time"
+
+	"golang.org/x/oauth2"
+
+	"github.com/taiypeo/spotifygo/apierrors"
+	"github.com/taiypeo/spotifygo/tokenauth"
+)
+
+// ToOAuth2Token converts a tokenauth token to an oauth2.Token.
+// token has to be an *AuthToken, *ScopedAuthToken, *RefreshableAuthToken,
+// *PKCERefreshableAuthToken or *TokenSource.
+func ToOAuth2Token(token tokenauth.Token) (*oauth2.Token, apierrors.TypedError) {
+	if source, ok := token.(*tokenauth.TokenSource); ok {
+		token = source.Current()
+	}
+
+	var authToken *tokenauth.AuthToken
+	var refreshToken, scope string
+	switch typedToken := token.(type) {
+	case *tokenauth.AuthToken:
+		authToken = typedToken
+	case *tokenauth.ScopedAuthToken:
+		authToken = &typedToken.AuthToken
+		scope = typedToken.ScopeString
+	case *tokenauth.RefreshableAuthToken:
+		authToken = &typedToken.AuthToken
+		scope = typedToken.ScopeString
+		refreshToken = typedToken.RefreshToken
+	case *tokenauth.PKCERefreshableAuthToken:
+		authToken = &typedToken.AuthToken
+		scope = typedToken.ScopeString
+		refreshToken = typedToken.RefreshToken
+	default:
+		return nil, apierrors.NewBasicErrorFromString("Unknown token type")
+	}
+
+	oauth2Token := &oauth2.Token{
+		AccessToken:  authToken.AccessToken,
+		TokenType:    authToken.TokenType,
+		RefreshToken: refreshToken,
+		Expiry: authToken.CreationTime.Add(
+			time.Duration(authToken.ExpiresIn) * time.Second,
+		),
+	}
+	if scope != "" {
+		oauth2Token = oauth2Token.WithExtra(map[string]interface{}{"scope": scope})
+	}
+
+	return oauth2Token, nil
+}
+
+// FromOAuth2Token converts an oauth2.Token obtained from the Spotify accounts
+// service (for example, by oauth2.Config.Exchange) to a RefreshableAuthToken.
+func FromOAuth2Token(token *oauth2.Token) tokenauth.RefreshableAuthToken {
+	var refreshableToken tokenauth.RefreshableAuthToken
+	refreshableToken.AccessToken = token.AccessToken
+	refreshableToken.TokenType = token.Type()
+	refreshableToken.RefreshToken = token.RefreshToken
+	refreshableToken.CreationTime = time.Now()
+	if !token.Expiry.IsZero() {
+		refreshableToken.ExpiresIn = int64(time.Until(token.Expiry) / time.Second)
+	}
+	if scope, ok := token.Extra("scope").(string); ok {
+		refreshableToken.ScopeString = scope
+		refreshableToken.Scope = strings.Split(scope, " ")
+	}
+
+	return refreshableToken
+}
+
+// tokenSource is an oauth2.TokenSource backed by a tokenauth token.
+type tokenSource struct {
+	token tokenauth.Token
+}
+
+// Token returns the current token, so tokenSource implements oauth2.TokenSource.
+func (source *tokenSource) Token() (*oauth2.Token, error) {
+	if tokenSource, ok := source.token.(*tokenauth.TokenSource); ok {
+		// Refreshes the token if it is about to expire.
+		if _, err := tokenSource.TokenWithContext(context.Background()); err != nil {
+			return nil, err
+		}
+	}
+
+	oauth2Token, err := ToOAuth2Token(source.token)
+	if err != nil {
+		return nil, err
+	}
+
+	return oauth2Token, nil
+}
+
+// TokenSource returns an oauth2.TokenSource that returns the given token.
+// If token is a *tokenauth.TokenSource, the token is refreshed when it is
+// about to expire; other tokens are returned as they are.
+func TokenSource(token tokenauth.Token) oauth2.TokenSource {
+	return &tokenSource{token}
+}
+
+// Token is a tokenauth.Token backed by an oauth2.TokenSource,
+// so that tokens from existing oauth2 plumbing can be used with the restapi packages.
+type Token struct {
+	source oauth2.TokenSource
+}
+
+// NewToken creates a new Token from an oauth2.TokenSource.
+// Wrap the source in oauth2.ReuseTokenSource if it does not cache tokens itself.
+func NewToken(source oauth2.TokenSource) *Token {
+	return &Token{source}
+}
+
+// TokenWithError returns the authorization value of the current token,
+// or the error of the oauth2.TokenSource.
+func (token *Token) TokenWithError() (string, apierrors.TypedError) {
+	oauth2Token, err := token.source.Token()
+	if err != nil {
+		return "", apierrors.NewBasicErrorFromError(err)
+	}
+
+	return oauth2Token.Type() + " " + oauth2Token.AccessToken, nil
+}
+
+// GetToken returns a token that is used in Spotify REST API to authorize
+// user actions, so Token implements tokenauth.Token.
+// If the oauth2.TokenSource fails, an empty string is returned and the request
+// that uses it fails; use TokenWithError to get the error instead.
+func (token *Token) GetToken() string {
+	authorization, err := token.TokenWithError()
+	if err != nil {
+		return ""
+	}
+
+	return authorization
+}
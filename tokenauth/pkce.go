@@ -1,8 +1,9 @@
 package tokenauth
 
 import (
+	"context"
 	"encoding/json"
-	"fmt"
+	"net/url"
 	"strings"
 	"time"
 
@@ -34,22 +35,63 @@ func (auth *PKCERefreshableAuthToken) Validate() apierrors.TypedError {
 // clientID is the Spotify application client id;
 // code is the authorization code obtained after the user is redirected to redirectURI;
 // redirectURI is the same redirect_uri that was supplied when requesting the authorization code;
-// codeVerifier is the same code verifier that you generate in the beginning of this method
+// codeVerifier is the code verifier (see NewCodeVerifier) whose challenge was passed to /authorize.
 func NewPKCERefreshableAuthToken(
 	authCode,
 	redirectURI,
 	clientID,
 	codeVerifier string,
 ) (PKCERefreshableAuthToken, apierrors.TypedError) {
-	payload := fmt.Sprintf(
-		"client_id=%s&grant_type=authorization_code&code=%s&redirect_uri=%s&code_verifier=%s",
+	return NewPKCERefreshableAuthTokenWithClient(
+		requests.DefaultClient,
+		authCode,
+		redirectURI,
 		clientID,
+		codeVerifier,
+	)
+}
+
+// NewPKCERefreshableAuthTokenWithClient is the same as NewPKCERefreshableAuthToken,
+// but performs the request using the given client.
+func NewPKCERefreshableAuthTokenWithClient(
+	client *requests.Client,
+	authCode,
+	redirectURI,
+	clientID,
+	codeVerifier string,
+) (PKCERefreshableAuthToken, apierrors.TypedError) {
+	return NewPKCERefreshableAuthTokenWithContext(
+		context.Background(),
+		client,
 		authCode,
 		redirectURI,
+		clientID,
 		codeVerifier,
 	)
+}
 
-	response, err := requests.PostAuthorization(map[string]string{}, payload)
+// NewPKCERefreshableAuthTokenWithContext is the same as
+// NewPKCERefreshableAuthTokenWithClient, but performs the request with the given context.
+// A nil client means requests.DefaultClient.
+func NewPKCERefreshableAuthTokenWithContext(
+	ctx context.Context,
+	client *requests.Client,
+	authCode,
+	redirectURI,
+	clientID,
+	codeVerifier string,
+) (PKCERefreshableAuthToken, apierrors.TypedError) {
+	response, err := client.PostTokenWithContext(
+		ctx,
+		url.Values{
+			"grant_type":    {"authorization_code"},
+			"code":          {authCode},
+			"redirect_uri":  {redirectURI},
+			"code_verifier": {codeVerifier},
+		},
+		clientID,
+		"",
+	)
 	if err != nil {
 		return PKCERefreshableAuthToken{}, err
 	}
@@ -76,13 +118,31 @@ func NewPKCERefreshableAuthToken(
 // Refresh refreshes the access token using the refresh token.
 // clientId is the Spotify application client id.
 func (auth *PKCERefreshableAuthToken) Refresh(clientID string) apierrors.TypedError {
-	payload := fmt.Sprintf(
-		"grant_type=refresh_token&refresh_token=%s&client_id=%s",
-		auth.RefreshToken,
+	return auth.RefreshWithClient(requests.DefaultClient, clientID)
+}
+
+// RefreshWithClient is the same as Refresh, but performs
+// the request using the given client.
+func (auth *PKCERefreshableAuthToken) RefreshWithClient(
+	client *requests.Client,
+	clientID string,
+) apierrors.TypedError {
+	return auth.RefreshWithContext(context.Background(), client, clientID)
+}
+
+// RefreshWithContext is the same as RefreshWithClient, but performs
+// the request with the given context. A nil client means requests.DefaultClient.
+func (auth *PKCERefreshableAuthToken) RefreshWithContext(
+	ctx context.Context,
+	client *requests.Client,
+	clientID string,
+) apierrors.TypedError {
+	response, err := client.PostTokenWithContext(
+		ctx,
+		url.Values{"grant_type": {"refresh_token"}, "refresh_token": {auth.RefreshToken}},
 		clientID,
+		"",
 	)
-
-	response, err := requests.PostAuthorization(map[string]string{}, payload)
 	if err != nil {
 		return err
 	}
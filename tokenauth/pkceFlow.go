@@ -0,0 +1,137 @@
+package tokenauth
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+
+	"github.com/taiypeo/spotifygo/apierrors"
+	"github.com/taiypeo/spotifygo/requests"
+)
+
+// codeVerifierLength is the number of random bytes in a code verifier,
+// which results in a 86 characters long verifier (RFC 7636 allows 43 to 128).
+const codeVerifierLength = 64
+
+// NewCodeVerifier generates a random PKCE code verifier as per RFC 7636.
+// The verifier only consists of unreserved URL characters.
+func NewCodeVerifier() (string, apierrors.TypedError) {
+	bytes := make([]byte, codeVerifierLength)
+	if _, err := rand.Read(bytes); err != nil {
+		return "", apierrors.NewBasicErrorFromError(err)
+	}
+
+	return base64.RawURLEncoding.EncodeToString(bytes), nil
+}
+
+// GetCodeChallenge returns the S256 code challenge of a code verifier,
+// which is passed to GetPKCEAuthorizationURL.
+func GetCodeChallenge(codeVerifier string) string {
+	verifierHash := sha256.Sum256([]byte(codeVerifier))
+	return base64.RawURLEncoding.EncodeToString(verifierHash[:])
+}
+
+// PKCEFlow is the state of an authorization code flow with proof key for
+// code exchange (PKCE) that has to be kept between redirecting the user
+// to /authorize and exchanging the authorization code.
+// The struct can be stored (for example, in a session) and restored,
+// as long as State and CodeVerifier stay secret.
+type PKCEFlow struct {
+	ClientID     string
+	RedirectURI  string
+	Scopes       []Scope
+	ShowDialog   bool
+	State        string
+	CodeVerifier string
+}
+
+// NewPKCEFlow starts a new PKCEFlow with a random state and code verifier.
+// clientID is the Spotify application client id;
+// redirectURI is the URI the user is redirected to after the authorization;
+// scopes are the requested scopes.
+func NewPKCEFlow(
+	clientID,
+	redirectURI string,
+	scopes []Scope,
+) (*PKCEFlow, apierrors.TypedError) {
+	state, err := NewState()
+	if err != nil {
+		return nil, err
+	}
+
+	codeVerifier, err := NewCodeVerifier()
+	if err != nil {
+		return nil, err
+	}
+
+	return &PKCEFlow{
+		ClientID:     clientID,
+		RedirectURI:  redirectURI,
+		Scopes:       scopes,
+		State:        state,
+		CodeVerifier: codeVerifier,
+	}, nil
+}
+
+// AuthorizationURL returns the URL of the /authorize endpoint that
+// the user has to visit.
+func (flow *PKCEFlow) AuthorizationURL() (string, apierrors.TypedError) {
+	return flow.AuthorizationURLWithClient(requests.DefaultClient)
+}
+
+// AuthorizationURLWithClient is the same as AuthorizationURL, but uses
+// the accounts service URL of the given client.
+func (flow *PKCEFlow) AuthorizationURLWithClient(
+	client *requests.Client,
+) (string, apierrors.TypedError) {
+	return GetPKCEAuthorizationURL(
+		client,
+		flow.ClientID,
+		flow.RedirectURI,
+		flow.State,
+		GetCodeChallenge(flow.CodeVerifier),
+		flow.Scopes,
+		flow.ShowDialog,
+	)
+}
+
+// Exchange verifies the URL the user was redirected to and exchanges
+// the authorization code for a PKCERefreshableAuthToken.
+// callbackURL is the full redirect URL with its query.
+func (flow *PKCEFlow) Exchange(
+	callbackURL string,
+) (PKCERefreshableAuthToken, apierrors.TypedError) {
+	return flow.ExchangeWithClient(requests.DefaultClient, callbackURL)
+}
+
+// ExchangeWithClient is the same as Exchange, but performs
+// the request using the given client.
+func (flow *PKCEFlow) ExchangeWithClient(
+	client *requests.Client,
+	callbackURL string,
+) (PKCERefreshableAuthToken, apierrors.TypedError) {
+	return flow.ExchangeWithContext(context.Background(), client, callbackURL)
+}
+
+// ExchangeWithContext is the same as ExchangeWithClient, but performs
+// the request with the given context. A nil client means requests.DefaultClient.
+func (flow *PKCEFlow) ExchangeWithContext(
+	ctx context.Context,
+	client *requests.Client,
+	callbackURL string,
+) (PKCERefreshableAuthToken, apierrors.TypedError) {
+	code, err := ParseAuthorizationCallback(callbackURL, flow.State)
+	if err != nil {
+		return PKCERefreshableAuthToken{}, err
+	}
+
+	return NewPKCERefreshableAuthTokenWithContext(
+		ctx,
+		client,
+		code,
+		flow.RedirectURI,
+		flow.ClientID,
+		flow.CodeVerifier,
+	)
+}
@@ -0,0 +1,182 @@
+package tokenauth
+
+import (
+	"strings"
+
+	"github.com/taiypeo/spotifygo/apierrors"
+)
+
+// Scope represents an authorization scope of the Spotify Web API.
+type Scope int
+
+const (
+	// UGCImageUpload is the 'ugc-image-upload' scope.
+	UGCImageUpload Scope = iota
+	// UserReadPlaybackState is the 'user-read-playback-state' scope.
+	UserReadPlaybackState
+	// UserModifyPlaybackState is the 'user-modify-playback-state' scope.
+	UserModifyPlaybackState
+	// UserReadCurrentlyPlaying is the 'user-read-currently-playing' scope.
+	UserReadCurrentlyPlaying
+	// AppRemoteControl is the 'app-remote-control' scope.
+	AppRemoteControl
+	// Streaming is the 'streaming' scope.
+	Streaming
+	// PlaylistReadPrivate is the 'playlist-read-private' scope.
+	PlaylistReadPrivate
+	// PlaylistReadCollaborative is the 'playlist-read-collaborative' scope.
+	PlaylistReadCollaborative
+	// PlaylistModifyPrivate is the 'playlist-modify-private' scope.
+	PlaylistModifyPrivate
+	// PlaylistModifyPublic is the 'playlist-modify-public' scope.
+	PlaylistModifyPublic
+	// UserFollowModify is the 'user-follow-modify' scope.
+	UserFollowModify
+	// UserFollowRead is the 'user-follow-read' scope.
+	UserFollowRead
+	// UserReadPlaybackPosition is the 'user-read-playback-position' scope.
+	UserReadPlaybackPosition
+	// UserTopRead is the 'user-top-read' scope.
+	UserTopRead
+	// UserReadRecentlyPlayed is the 'user-read-recently-played' scope.
+	UserReadRecentlyPlayed
+	// UserLibraryModify is the 'user-library-modify' scope.
+	UserLibraryModify
+	// UserLibraryRead is the 'user-library-read' scope.
+	UserLibraryRead
+	// UserReadEmail is the 'user-read-email' scope.
+	UserReadEmail
+	// UserReadPrivate is the 'user-read-private' scope.
+	UserReadPrivate
+	// UserPersonalized is the 'user-personalized' scope.
+	UserPersonalized
+	// UserSOALink is the 'user-soa-link' scope of Spotify Open Access.
+	UserSOALink
+	// UserSOAUnlink is the 'user-soa-unlink' scope of Spotify Open Access.
+	UserSOAUnlink
+	// SOAManageEntitlements is the 'soa-manage-entitlements' scope of Spotify Open Access.
+	SOAManageEntitlements
+	// SOAManagePartner is the 'soa-manage-partner' scope of Spotify Open Access.
+	SOAManagePartner
+	// SOACreatePartner is the 'soa-create-partner' scope of Spotify Open Access.
+	SOACreatePartner
+)
+
+func (scope Scope) String() (string, apierrors.TypedError) {
+	scopeString, ok := map[Scope]string{
+		UGCImageUpload:            "ugc-image-upload",
+		UserReadPlaybackState:     "user-read-playback-state",
+		UserModifyPlaybackState:   "user-modify-playback-state",
+		UserReadCurrentlyPlaying:  "user-read-currently-playing",
+		AppRemoteControl:          "app-remote-control",
+		Streaming:                 "streaming",
+		PlaylistReadPrivate:       "playlist-read-private",
+		PlaylistReadCollaborative: "playlist-read-collaborative",
+		PlaylistModifyPrivate:     "playlist-modify-private",
+		PlaylistModifyPublic:      "playlist-modify-public",
+		UserFollowModify:          "user-follow-modify",
+		UserFollowRead:            "user-follow-read",
+		UserReadPlaybackPosition:  "user-read-playback-position",
+		UserTopRead:               "user-top-read",
+		UserReadRecentlyPlayed:    "user-read-recently-played",
+		UserLibraryModify:         "user-library-modify",
+		UserLibraryRead:           "user-library-read",
+		UserReadEmail:             "user-read-email",
+		UserReadPrivate:           "user-read-private",
+		UserPersonalized:          "user-personalized",
+		UserSOALink:               "user-soa-link",
+		UserSOAUnlink:             "user-soa-unlink",
+		SOAManageEntitlements:     "soa-manage-entitlements",
+		SOAManagePartner:          "soa-manage-partner",
+		SOACreatePartner:          "soa-create-partner",
+	}[scope]
+	if !ok {
+		return "", apierrors.NewBasicErrorFromString("Unknown scope")
+	}
+
+	return scopeString, nil
+}
+
+// joinScopes returns the space-separated list of scopes that is used
+// in the scope parameter.
+func joinScopes(scopes []Scope) (string, apierrors.TypedError) {
+	scopeStrings := make([]string, 0, len(scopes))
+	for _, scope := range scopes {
+		scopeString, err := scope.String()
+		if err != nil {
+			return "", err
+		}
+
+		scopeStrings = append(scopeStrings, scopeString)
+	}
+
+	return strings.Join(scopeStrings, " "), nil
+}
+
+// grantedScopes returns the scopes that the token is known to be granted.
+// The result is empty if the token carries no scope information, such as
+// tokens from the implicit grant flow, whose redirect does not include the scopes.
+func (auth *ScopedAuthToken) grantedScopes() []string {
+	if auth.Scope != nil {
+		return auth.Scope
+	}
+
+	// Scope is not serialized, so it may be missing after unmarshaling.
+	return strings.Fields(auth.ScopeString)
+}
+
+// HasScopes returns true if the token is known to be granted all of the given scopes.
+// For tokens without scope information (see CheckScopes) it returns false.
+func (auth *ScopedAuthToken) HasScopes(scopes ...Scope) bool {
+	grantedScopes := auth.grantedScopes()
+	for _, scope := range scopes {
+		scopeString, err := scope.String()
+		if err != nil || !stringInSlice(scopeString, grantedScopes) {
+			return false
+		}
+	}
+
+	return true
+}
+
+func stringInSlice(str string, slice []string) bool {
+	for _, sliceStr := range slice {
+		if sliceStr == str {
+			return true
+		}
+	}
+
+	return false
+}
+
+// scopedToken is a Token that may know which scopes it was granted.
+type scopedToken interface {
+	Token
+	HasScopes(scopes ...Scope) bool
+	grantedScopes() []string
+}
+
+// CheckScopes returns a BasicError if the token provably lacks some of the given scopes,
+// that is, if it is a ScopedAuthToken, RefreshableAuthToken, PKCERefreshableAuthToken
+// or a TokenSource of one of them, and its non-empty set of granted scopes
+// does not contain all of the scopes.
+// Tokens that do not carry their scopes, such as AuthToken, tokens from
+// the implicit grant flow and tokens created with nil scopes, always pass the check,
+// and Spotify decides whether the request is allowed.
+func CheckScopes(token Token, scopes ...Scope) apierrors.TypedError {
+	if source, ok := token.(*TokenSource); ok {
+		token = source.Current()
+	}
+
+	scopedToken, ok := token.(scopedToken)
+	if !ok || len(scopedToken.grantedScopes()) == 0 || scopedToken.HasScopes(scopes...) {
+		return nil
+	}
+
+	scope, err := joinScopes(scopes)
+	if err != nil {
+		return err
+	}
+
+	return apierrors.NewBasicErrorFromString("The token lacks the required scopes: " + scope)
+}
@@ -0,0 +1,212 @@
+package tokenauth
+
+import (
+	"errors"
+	"sync"
+
+	"github.com/taiypeo/spotifygo/apierrors"
+	"github.com/taiypeo/spotifygo/requests"
+)
+
+// invalidGrantError is the error that the accounts service returns
+// for revoked or otherwise invalid refresh tokens.
+const invalidGrantError = "invalid_grant"
+
+// isInvalidGrantError returns true if err means that the refresh token was revoked.
+func isInvalidGrantError(err apierrors.TypedError) bool {
+	var authError *apierrors.AuthenticationError
+	return errors.As(err, &authError) && authError.ErrorHighLevel == invalidGrantError
+}
+
+// TokenManager manages the tokens of many users, keyed by their Spotify user IDs.
+// Tokens are persisted in a TokenStore and handed out as TokenSources,
+// which refresh them lazily, one refresh at a time per user.
+// Rotated tokens (such as PKCE refresh tokens, which change on every refresh)
+// are saved back to the store.
+// When a refresh fails with invalid_grant (the user revoked the access),
+// the user's token is deleted from the store, the user is flagged as revoked
+// and OnInvalidGrant, if not nil, is called with the user ID.
+// OnStoreError, if not nil, is called when a rotated token cannot be saved.
+// OnInvalidGrant and OnStoreError must be set before the TokenManager is used.
+// TokenManager is safe for concurrent use.
+type TokenManager struct {
+	OnInvalidGrant func(userID string)
+	OnStoreError   func(userID string, err apierrors.TypedError)
+
+	client       *requests.Client
+	store        TokenStore
+	clientID     string
+	clientSecret string
+
+	mutex   sync.Mutex
+	sources map[string]*TokenSource
+	revoked map[string]bool
+}
+
+// NewTokenManager creates a new TokenManager.
+// client is used for the token requests (requests.DefaultClient if nil);
+// store is where the tokens are persisted;
+// clientId is the Spotify application client id;
+// clientSecret is the Spotify application client secret (only used for
+// RefreshableAuthToken, so it can be empty if all users use PKCE).
+func NewTokenManager(
+	client *requests.Client,
+	store TokenStore,
+	clientID,
+	clientSecret string,
+) *TokenManager {
+	return &TokenManager{
+		client:       client,
+		store:        store,
+		clientID:     clientID,
+		clientSecret: clientSecret,
+		sources:      make(map[string]*TokenSource),
+		revoked:      make(map[string]bool),
+	}
+}
+
+// newTokenSource creates the TokenSource of a user, which saves rotated
+// tokens and handles revoked refresh tokens.
+func (manager *TokenManager) newTokenSource(
+	userID string,
+	token Token,
+) (*TokenSource, apierrors.TypedError) {
+	var source *TokenSource
+	switch typedToken := token.(type) {
+	case *ScopedAuthToken:
+		source = NewScopedTokenSource(*typedToken)
+	case *RefreshableAuthToken:
+		source = NewRefreshableTokenSource(
+			manager.client,
+			*typedToken,
+			manager.clientID,
+			manager.clientSecret,
+		)
+	case *PKCERefreshableAuthToken:
+		source = NewPKCETokenSource(manager.client, *typedToken, manager.clientID)
+	default:
+		return nil, apierrors.NewBasicErrorFromString(
+			"TokenManager only manages user tokens (ScopedAuthToken, " +
+				"RefreshableAuthToken or PKCERefreshableAuthToken)",
+		)
+	}
+
+	source.OnRotate = func(token Token) {
+		// A token replaced by Save in the meantime must not overwrite the new one.
+		manager.mutex.Lock()
+		current := manager.sources[userID] == source
+		manager.mutex.Unlock()
+		if !current {
+			return
+		}
+
+		if err := manager.store.Save(userID, token); err != nil && manager.OnStoreError != nil {
+			manager.OnStoreError(userID, err)
+		}
+	}
+	source.OnRefreshError = func(err apierrors.TypedError) {
+		if isInvalidGrantError(err) {
+			manager.revoke(userID, source)
+		}
+	}
+
+	return source, nil
+}
+
+// revoke forgets the token of a user whose refresh token was revoked,
+// unless source was already replaced by a newer token.
+func (manager *TokenManager) revoke(userID string, source *TokenSource) {
+	manager.mutex.Lock()
+	if manager.sources[userID] != source {
+		manager.mutex.Unlock()
+		return
+	}
+	delete(manager.sources, userID)
+	manager.revoked[userID] = true
+	manager.mutex.Unlock()
+
+	if err := manager.store.Delete(userID); err != nil && manager.OnStoreError != nil {
+		manager.OnStoreError(userID, err)
+	}
+
+	if manager.OnInvalidGrant != nil {
+		manager.OnInvalidGrant(userID)
+	}
+}
+
+// Save stores a newly obtained token of a user (for example, after the user
+// went through the authorization code flow), replacing the previous one
+// and clearing the revoked flag.
+// token has to be a *ScopedAuthToken, *RefreshableAuthToken or *PKCERefreshableAuthToken.
+func (manager *TokenManager) Save(userID string, token Token) apierrors.TypedError {
+	source, err := manager.newTokenSource(userID, token)
+	if err != nil {
+		return err
+	}
+
+	if err := manager.store.Save(userID, token); err != nil {
+		return err
+	}
+
+	manager.mutex.Lock()
+	defer manager.mutex.Unlock()
+
+	manager.sources[userID] = source
+	delete(manager.revoked, userID)
+	return nil
+}
+
+// Token returns a ready-to-use token of a user, loading it from the store
+// if needed. The returned TokenSource refreshes the token when it is about to expire.
+// A BasicError is returned if there is no token stored for the user.
+func (manager *TokenManager) Token(userID string) (*TokenSource, apierrors.TypedError) {
+	manager.mutex.Lock()
+	source, ok := manager.sources[userID]
+	manager.mutex.Unlock()
+	if ok {
+		return source, nil
+	}
+
+	token, ok, err := manager.store.Load(userID)
+	if err != nil {
+		return nil, err
+	}
+	if !ok {
+		return nil, apierrors.NewBasicErrorFromString("No token is stored for user " + userID)
+	}
+
+	source, err = manager.newTokenSource(userID, token)
+	if err != nil {
+		return nil, err
+	}
+
+	manager.mutex.Lock()
+	defer manager.mutex.Unlock()
+
+	// Another goroutine may have loaded the token in the meantime.
+	if existingSource, ok := manager.sources[userID]; ok {
+		return existingSource, nil
+	}
+
+	manager.sources[userID] = source
+	return source, nil
+}
+
+// Delete forgets the token of a user and removes it from the store.
+func (manager *TokenManager) Delete(userID string) apierrors.TypedError {
+	manager.mutex.Lock()
+	delete(manager.sources, userID)
+	delete(manager.revoked, userID)
+	manager.mutex.Unlock()
+
+	return manager.store.Delete(userID)
+}
+
+// Revoked returns true if the refresh token of a user was found to be revoked,
+// so the user has to authorize the application again.
+func (manager *TokenManager) Revoked(userID string) bool {
+	manager.mutex.Lock()
+	defer manager.mutex.Unlock()
+
+	return manager.revoked[userID]
+}
@@ -0,0 +1,128 @@
+package tokenauth
+
+import (
+	"encoding/json"
+	"time"
+
+	"github.com/taiypeo/spotifygo/apierrors"
+)
+
+// Token types stored in the type field of a serialized token.
+const (
+	clientCredentialsTokenType = "client_credentials"
+	implicitGrantTokenType     = "implicit_grant"
+	authorizationCodeTokenType = "authorization_code"
+	pkceTokenType              = "pkce"
+)
+
+// tokenRecord is the serialized form of every token type.
+// Scope and ScopeString are both stored, so that tokens round-trip exactly.
+type tokenRecord struct {
+	Type         string    `json:"type"`
+	AccessToken  string    `json:"access_token"`
+	TokenType    string    `json:"token_type"`
+	ExpiresIn    int64     `json:"expires_in"`
+	CreationTime time.Time `json:"creation_time"`
+	Scope        []string  `json:"scopes,omitempty"`
+	ScopeString  string    `json:"scope,omitempty"`
+	RefreshToken string    `json:"refresh_token,omitempty"`
+}
+
+func newScopedTokenRecord(recordType string, token *ScopedAuthToken) tokenRecord {
+	return tokenRecord{
+		Type:         recordType,
+		AccessToken:  token.AccessToken,
+		TokenType:    token.TokenType,
+		ExpiresIn:    token.ExpiresIn,
+		CreationTime: token.CreationTime,
+		Scope:        token.Scope,
+		ScopeString:  token.ScopeString,
+	}
+}
+
+func (record *tokenRecord) authToken() AuthToken {
+	return AuthToken{
+		AccessToken:  record.AccessToken,
+		TokenType:    record.TokenType,
+		ExpiresIn:    record.ExpiresIn,
+		CreationTime: record.CreationTime,
+	}
+}
+
+func (record *tokenRecord) scopedAuthToken() ScopedAuthToken {
+	return ScopedAuthToken{
+		Scope:       record.Scope,
+		ScopeString: record.ScopeString,
+		AuthToken:   record.authToken(),
+	}
+}
+
+// MarshalToken serializes a token, including its creation time and scopes,
+// so that UnmarshalToken restores it exactly.
+// token has to be an *AuthToken, *ScopedAuthToken, *RefreshableAuthToken,
+// *PKCERefreshableAuthToken or *TokenSource.
+func MarshalToken(token Token) ([]byte, apierrors.TypedError) {
+	if source, ok := token.(*TokenSource); ok {
+		token = source.Current()
+	}
+
+	var record tokenRecord
+	switch typedToken := token.(type) {
+	case *AuthToken:
+		record = tokenRecord{
+			Type:         clientCredentialsTokenType,
+			AccessToken:  typedToken.AccessToken,
+			TokenType:    typedToken.TokenType,
+			ExpiresIn:    typedToken.ExpiresIn,
+			CreationTime: typedToken.CreationTime,
+		}
+	case *ScopedAuthToken:
+		record = newScopedTokenRecord(implicitGrantTokenType, typedToken)
+	case *RefreshableAuthToken:
+		record = newScopedTokenRecord(authorizationCodeTokenType, &typedToken.ScopedAuthToken)
+		record.RefreshToken = typedToken.RefreshToken
+	case *PKCERefreshableAuthToken:
+		record = newScopedTokenRecord(pkceTokenType, &typedToken.ScopedAuthToken)
+		record.RefreshToken = typedToken.RefreshToken
+	default:
+		return nil, apierrors.NewBasicErrorFromString("Unknown token type")
+	}
+
+	data, err := json.Marshal(record)
+	if err != nil {
+		return nil, apierrors.NewBasicErrorFromError(err)
+	}
+
+	return data, nil
+}
+
+// UnmarshalToken restores a token serialized by MarshalToken.
+// The returned Token is an *AuthToken, *ScopedAuthToken, *RefreshableAuthToken
+// or *PKCERefreshableAuthToken.
+func UnmarshalToken(data []byte) (Token, apierrors.TypedError) {
+	var record tokenRecord
+	if err := json.Unmarshal(data, &record); err != nil {
+		return nil, apierrors.NewBasicErrorFromError(err)
+	}
+
+	switch record.Type {
+	case clientCredentialsTokenType:
+		token := record.authToken()
+		return &token, nil
+	case implicitGrantTokenType:
+		token := record.scopedAuthToken()
+		return &token, nil
+	case authorizationCodeTokenType:
+		return &RefreshableAuthToken{
+			RefreshToken:    record.RefreshToken,
+			ScopedAuthToken: record.scopedAuthToken(),
+		}, nil
+	case pkceTokenType:
+		return &PKCERefreshableAuthToken{
+			RefreshToken:    record.RefreshToken,
+			ScopedAuthToken: record.scopedAuthToken(),
+		}, nil
+	}
+
+	return nil, apierrors.NewBasicErrorFromString("Unknown token type " + record.Type)
+}
@@ -0,0 +1,307 @@
+package tokenauth
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/taiypeo/spotifygo/apierrors"
+	"github.com/taiypeo/spotifygo/requests"
+)
+
+// DefaultExpiryMargin is the default time before the expiration of an access token
+// at which TokenSource refreshes it.
+const DefaultExpiryMargin = time.Minute
+
+// refreshableToken is a token that TokenSource can keep valid.
+type refreshableToken interface {
+	Token
+	expiresAt() time.Time
+	refreshed(
+		ctx context.Context,
+		client *requests.Client,
+		clientID,
+		clientSecret string,
+	) (refreshableToken, apierrors.TypedError)
+}
+
+func (auth *AuthToken) expiresAt() time.Time {
+	return auth.CreationTime.Add(time.Duration(auth.ExpiresIn) * time.Second)
+}
+
+func (auth *AuthToken) refreshed(
+	ctx context.Context,
+	client *requests.Client,
+	clientID,
+	clientSecret string,
+) (refreshableToken, apierrors.TypedError) {
+	newToken, err := NewAuthTokenWithContext(ctx, client, clientID, clientSecret)
+	if err != nil {
+		return nil, err
+	}
+
+	return &newToken, nil
+}
+
+func (auth *ScopedAuthToken) refreshed(
+	context.Context,
+	*requests.Client,
+	string,
+	string,
+) (refreshableToken, apierrors.TypedError) {
+	return nil, apierrors.NewBasicErrorFromString(
+		"ScopedAuthToken cannot be refreshed, the implicit grant flow has to be repeated",
+	)
+}
+
+func (auth *RefreshableAuthToken) refreshed(
+	ctx context.Context,
+	client *requests.Client,
+	clientID,
+	clientSecret string,
+) (refreshableToken, apierrors.TypedError) {
+	newToken := *auth
+	if err := newToken.RefreshWithContext(ctx, client, clientID, clientSecret); err != nil {
+		return nil, err
+	}
+
+	return &newToken, nil
+}
+
+func (auth *PKCERefreshableAuthToken) refreshed(
+	ctx context.Context,
+	client *requests.Client,
+	clientID,
+	_ string,
+) (refreshableToken, apierrors.TypedError) {
+	newToken := *auth
+	if err := newToken.RefreshWithContext(ctx, client, clientID); err != nil {
+		return nil, err
+	}
+
+	return &newToken, nil
+}
+
+// refreshTimeout limits the time that a refresh shared by several callers can take.
+const refreshTimeout = time.Minute
+
+// refreshCall is an in-flight refresh that concurrent callers wait for.
+type refreshCall struct {
+	done chan struct{}
+	err  apierrors.TypedError
+}
+
+// TokenSource is a Token that keeps an access token valid: when the token is about
+// to expire (ExpiryMargin before CreationTime + ExpiresIn), it is refreshed
+// (or, for client credentials, requested again) transparently.
+// TokenSource is safe for concurrent use, and concurrent callers that need
+// a refresh trigger only one refresh request.
+// OnRotate, if not nil, is called with a copy of the new token (*AuthToken,
+// *ScopedAuthToken, *RefreshableAuthToken or *PKCERefreshableAuthToken) after
+// every refresh, for example to persist a rotated refresh token.
+// OnRefreshError, if not nil, is called with the error of every failed refresh.
+// Both are called before the callers waiting for the refresh return.
+// ExpiryMargin, OnRotate and OnRefreshError must be set before the TokenSource is used.
+type TokenSource struct {
+	ExpiryMargin   time.Duration
+	OnRotate       func(token Token)
+	OnRefreshError func(err apierrors.TypedError)
+
+	client       *requests.Client
+	clientID     string
+	clientSecret string
+
+	mutex sync.Mutex
+	token refreshableToken
+	call  *refreshCall
+}
+
+func newTokenSource(
+	client *requests.Client,
+	token refreshableToken,
+	clientID,
+	clientSecret string,
+) *TokenSource {
+	return &TokenSource{
+		ExpiryMargin: DefaultExpiryMargin,
+		client:       client,
+		clientID:     clientID,
+		clientSecret: clientSecret,
+		token:        token,
+	}
+}
+
+// NewClientCredentialsTokenSource creates a new TokenSource that obtains tokens
+// with the client credentials flow. The first token is requested when it is first needed.
+// client is used for the token requests (requests.DefaultClient if nil);
+// clientId is the Spotify application client id;
+// clientSecret is the Spotify application client secret.
+func NewClientCredentialsTokenSource(
+	client *requests.Client,
+	clientID,
+	clientSecret string,
+) *TokenSource {
+	return newTokenSource(client, &AuthToken{}, clientID, clientSecret)
+}
+
+// NewScopedTokenSource creates a new TokenSource from a ScopedAuthToken.
+// As tokens from implicit grant flow cannot be refreshed, the TokenSource
+// returns an error once the token expires.
+func NewScopedTokenSource(token ScopedAuthToken) *TokenSource {
+	return newTokenSource(nil, &token, "", "")
+}
+
+// NewRefreshableTokenSource creates a new TokenSource that refreshes
+// a RefreshableAuthToken.
+// client is used for the token requests (requests.DefaultClient if nil);
+// clientId is the Spotify application client id;
+// clientSecret is the Spotify application client secret.
+func NewRefreshableTokenSource(
+	client *requests.Client,
+	token RefreshableAuthToken,
+	clientID,
+	clientSecret string,
+) *TokenSource {
+	return newTokenSource(client, &token, clientID, clientSecret)
+}
+
+// NewPKCETokenSource creates a new TokenSource that refreshes
+// a PKCERefreshableAuthToken.
+// client is used for the token requests (requests.DefaultClient if nil);
+// clientId is the Spotify application client id.
+func NewPKCETokenSource(
+	client *requests.Client,
+	token PKCERefreshableAuthToken,
+	clientID string,
+) *TokenSource {
+	return newTokenSource(client, &token, clientID, "")
+}
+
+// valid must be called with the mutex held.
+func (source *TokenSource) valid(now time.Time) bool {
+	return source.token.expiresAt().Add(-source.ExpiryMargin).After(now)
+}
+
+// refresh refreshes the token. If force is false, the token is only refreshed
+// if it is about to expire; if force is true, it is refreshed unless its
+// authorization value has already changed from staleToken.
+// If ctx is done before the refresh finishes, a ContextError is returned,
+// but the refresh goes on for the other callers.
+func (source *TokenSource) refresh(
+	ctx context.Context,
+	force bool,
+	staleToken string,
+) apierrors.TypedError {
+	source.mutex.Lock()
+	if (!force && source.valid(time.Now())) || (force && source.token.GetToken() != staleToken) {
+		source.mutex.Unlock()
+		return nil
+	}
+
+	call := source.call
+	if call == nil {
+		call = &refreshCall{done: make(chan struct{})}
+		source.call = call
+		go source.runRefresh(call, source.token)
+	}
+	source.mutex.Unlock()
+
+	select {
+	case <-call.done:
+		return call.err
+	case <-ctx.Done():
+		return apierrors.NewContextError(ctx.Err())
+	}
+}
+
+// runRefresh performs a refresh shared by all the waiting callers.
+// It does not use the context of any of them, so that a caller that stops
+// waiting does not make the refresh fail for the others; refreshTimeout
+// limits it instead.
+func (source *TokenSource) runRefresh(call *refreshCall, token refreshableToken) {
+	ctx, cancel := context.WithTimeout(context.Background(), refreshTimeout)
+	defer cancel()
+
+	newToken, err := token.refreshed(ctx, source.client, source.clientID, source.clientSecret)
+
+	source.mutex.Lock()
+	if err == nil {
+		source.token = newToken
+	}
+	source.call = nil
+	source.mutex.Unlock()
+
+	if err == nil && source.OnRotate != nil {
+		source.OnRotate(copyToken(newToken))
+	} else if err != nil && source.OnRefreshError != nil {
+		source.OnRefreshError(err)
+	}
+
+	call.err = err
+	close(call.done)
+}
+
+func copyToken(token refreshableToken) Token {
+	switch typedToken := token.(type) {
+	case *AuthToken:
+		tokenCopy := *typedToken
+		return &tokenCopy
+	case *ScopedAuthToken:
+		tokenCopy := *typedToken
+		return &tokenCopy
+	case *RefreshableAuthToken:
+		tokenCopy := *typedToken
+		return &tokenCopy
+	case *PKCERefreshableAuthToken:
+		tokenCopy := *typedToken
+		return &tokenCopy
+	}
+
+	return token
+}
+
+// TokenWithContext returns a valid token that is used in Spotify REST API to authorize
+// user actions, refreshing it first if it is about to expire.
+func (source *TokenSource) TokenWithContext(ctx context.Context) (string, apierrors.TypedError) {
+	if err := source.refresh(ctx, false, ""); err != nil {
+		return "", err
+	}
+
+	source.mutex.Lock()
+	defer source.mutex.Unlock()
+
+	return source.token.GetToken(), nil
+}
+
+// GetToken returns a valid token that is used in Spotify REST API to authorize
+// user actions, so TokenSource implements Token.
+// If the refresh fails, the last known token is returned and the request that
+// uses it fails; use TokenWithContext to get the refresh error instead.
+func (source *TokenSource) GetToken() string {
+	source.refresh(context.Background(), false, "")
+
+	source.mutex.Lock()
+	defer source.mutex.Unlock()
+
+	return source.token.GetToken()
+}
+
+// ForceRefresh refreshes the token even if it is not about to expire,
+// unless it was already refreshed since staleToken (a value previously returned
+// by GetToken) was obtained, so that concurrent callers that saw the same
+// rejected token trigger only one refresh.
+func (source *TokenSource) ForceRefresh(
+	ctx context.Context,
+	staleToken string,
+) apierrors.TypedError {
+	return source.refresh(ctx, true, staleToken)
+}
+
+// Current returns a copy of the current token (*AuthToken, *ScopedAuthToken,
+// *RefreshableAuthToken or *PKCERefreshableAuthToken) without refreshing it.
+func (source *TokenSource) Current() Token {
+	source.mutex.Lock()
+	defer source.mutex.Unlock()
+
+	return copyToken(source.token)
+}
@@ -0,0 +1,71 @@
+package tokenauth
+
+import (
+	"sync"
+
+	"github.com/taiypeo/spotifygo/apierrors"
+)
+
+// TokenStore persists tokens under string keys, such as Spotify user IDs.
+// Load returns false if there is no token stored under key.
+// Tokens are stored with MarshalToken, so Load returns an *AuthToken,
+// *ScopedAuthToken, *RefreshableAuthToken or *PKCERefreshableAuthToken.
+// Implementations must be safe for concurrent use.
+type TokenStore interface {
+	Load(key string) (Token, bool, apierrors.TypedError)
+	Save(key string, token Token) apierrors.TypedError
+	Delete(key string) apierrors.TypedError
+}
+
+// MemoryTokenStore is a TokenStore that keeps tokens in memory.
+// It is useful for tests and short-lived processes.
+type MemoryTokenStore struct {
+	mutex  sync.Mutex
+	tokens map[string][]byte
+}
+
+// NewMemoryTokenStore creates a new empty MemoryTokenStore.
+func NewMemoryTokenStore() *MemoryTokenStore {
+	return &MemoryTokenStore{tokens: make(map[string][]byte)}
+}
+
+// Load returns the token stored under key, so MemoryTokenStore implements TokenStore.
+func (store *MemoryTokenStore) Load(key string) (Token, bool, apierrors.TypedError) {
+	store.mutex.Lock()
+	data, ok := store.tokens[key]
+	store.mutex.Unlock()
+
+	if !ok {
+		return nil, false, nil
+	}
+
+	token, err := UnmarshalToken(data)
+	if err != nil {
+		return nil, false, err
+	}
+
+	return token, true, nil
+}
+
+// Save stores a token under key, so MemoryTokenStore implements TokenStore.
+func (store *MemoryTokenStore) Save(key string, token Token) apierrors.TypedError {
+	data, err := MarshalToken(token)
+	if err != nil {
+		return err
+	}
+
+	store.mutex.Lock()
+	defer store.mutex.Unlock()
+
+	store.tokens[key] = data
+	return nil
+}
+
+// Delete removes the token stored under key, so MemoryTokenStore implements TokenStore.
+func (store *MemoryTokenStore) Delete(key string) apierrors.TypedError {
+	store.mutex.Lock()
+	defer store.mutex.Unlock()
+
+	delete(store.tokens, key)
+	return nil
+}